@@ -0,0 +1,198 @@
+package readability
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Author of an article.
+type Author struct {
+	Name  string
+	URL   string
+	Email string
+	// social media handle, e.g. "@jane", for the authors only known by it
+	Handle string
+}
+
+// Merges the authors found in the given sources, in order of preference. The authors are
+// those of the first source naming any: the other sources only complete their URL and email.
+func mergeAuthors(sources ...[]Author) []Author {
+	var authors, anonymous []Author
+	var primary = -1
+	for i, source := range sources {
+		for _, author := range source {
+			switch j := indexAuthor(authors, author); {
+			case author == (Author{}):
+			case j >= 0:
+				authors[j] = completeAuthor(authors[j], author)
+			case author.Name == "":
+				anonymous = append(anonymous, author)
+			case primary == -1 || primary == i:
+				primary = i
+				authors = append(authors, author)
+			}
+		}
+	}
+	if len(authors) == 0 {
+		return anonymous
+	}
+	// Authors only known by their page or email can still complete the named ones.
+	for _, author := range anonymous {
+		if j := indexAuthor(authors, author); j >= 0 {
+			authors[j] = completeAuthor(authors[j], author)
+		}
+	}
+	return authors
+}
+
+// Fills the missing fields of the given author with those of the other.
+func completeAuthor(author, other Author) Author {
+	return Author{
+		Name:   anyOf(author.Name, other.Name),
+		URL:    anyOf(author.URL, other.URL),
+		Email:  anyOf(author.Email, other.Email),
+		Handle: anyOf(author.Handle, other.Handle),
+	}
+}
+
+// Returns the index of the given author in authors, or -1. Authors are matched by name,
+// URL, email or handle. An author without name matches the only author, if it has no URL.
+func indexAuthor(authors []Author, author Author) int {
+	for i, a := range authors {
+		if author.Name != "" && strings.EqualFold(a.Name, author.Name) ||
+			author.URL != "" && a.URL == author.URL ||
+			author.Email != "" && strings.EqualFold(a.Email, author.Email) ||
+			author.Handle != "" && strings.EqualFold(a.Handle, author.Handle) {
+			return i
+		}
+	}
+	if author.Name == "" && len(authors) == 1 && authors[0].URL == "" {
+		return 0
+	}
+	return -1
+}
+
+// Returns the authors named in the given byline, e.g. "By Jane Doe and Richard Roe\n March 25, 2015".
+func (r *Readability) authorsFromByline(byline string) []Author {
+	var authors []Author
+	for _, name := range splitByline(cleanByline(byline)) {
+		var author Author
+		// Links to the author page and email addresses are sometimes part of the byline.
+		if url := bylineURL.FindString(name); url != "" {
+			author.URL = r.toAbsoluteURI(url)
+			name = strings.Replace(name, url, "", 1)
+		}
+		if email := bylineEmail.FindString(name); email != "" {
+			author.Email = email
+			name = strings.Replace(name, email, "", 1)
+		}
+		author.Name = strings.Trim(normalize.ReplaceAllString(name, " "), " <>()[]:,;-–—|")
+		if author != (Author{}) {
+			authors = append(authors, author)
+		}
+	}
+	return authors
+}
+
+// Returns the authors declared by the given meta tag values, which can be names,
+// bylines or, as for article:author, links to the author pages.
+func (r *Readability) authorsFromValues(values []string) []Author {
+	var authors []Author
+	for _, value := range values {
+		value = r.unescapeHtmlEntities(value)
+		if bylineURL.MatchString(value) && bylineURL.FindString(value) == strings.TrimSpace(value) {
+			authors = append(authors, Author{URL: r.toAbsoluteURI(strings.TrimSpace(value))})
+			continue
+		}
+		authors = append(authors, r.authorsFromByline(value)...)
+	}
+	return authors
+}
+
+// Returns the authors linked from the document with rel="author".
+func (r *Readability) authorsFromLinks(doc *Node) []Author {
+	var authors []Author
+	for _, n := range r.getAllNodesWithTag(doc, "a", "link") {
+		if !strings.Contains(" "+strings.ToLower(n.GetAttribute("rel"))+" ", " author ") {
+			continue
+		}
+		var href = strings.TrimSpace(n.GetAttribute("href"))
+		var author = Author{Name: strings.TrimSpace(normalize.ReplaceAllString(n.GetTextContent(), " "))}
+		if email, found := strings.CutPrefix(href, "mailto:"); found {
+			if unescaped, err := url.PathUnescape(email); err == nil {
+				email = unescaped
+			}
+			author.Email = email
+		} else if href != "" && !strings.HasPrefix(href, "#") && !strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			author.URL = r.toAbsoluteURI(href)
+		}
+		if author.Name != "" && !r.isValidByline(author.Name) {
+			continue
+		}
+		authors = append(authors, author)
+	}
+	return authors
+}
+
+// Returns the author declared by the given twitter:creator value, e.g. "@jane".
+func twitterAuthor(creator string) []Author {
+	var handle = strings.TrimPrefix(strings.TrimSpace(creator), "@")
+	if handle == "" || strings.ContainsAny(handle, " /") {
+		return nil
+	}
+	return []Author{{URL: "https://twitter.com/" + handle, Handle: "@" + handle}}
+}
+
+// Removes the "By" prefix, the dates and the other noise from the given byline.
+func cleanByline(byline string) string {
+	// Remarks in parentheses are dropped, unless they give the author page or email.
+	byline = bylineParentheses.ReplaceAllStringFunc(byline, func(s string) string {
+		if bylineURL.MatchString(s) || bylineEmail.MatchString(s) {
+			return s
+		}
+		return " "
+	})
+
+	var names []string
+	var prefixed, afterPrefix bool
+	for _, segment := range bylineSeparators.Split(byline, -1) {
+		segment = bylineDates.ReplaceAllString(segment, " ")
+		segment = strings.TrimSpace(normalize.ReplaceAllString(segment, " "))
+		if segment == "" {
+			continue
+		}
+		// When the byline announces its authors with a prefix, the segments without
+		// it are something else, e.g. the name of the publication or the editor.
+		if bylinePrefix.MatchString(segment) {
+			if !prefixed {
+				names = names[:0]
+			}
+			prefixed = true
+			segment = bylinePrefix.ReplaceAllString(segment, "")
+			// The prefix can be on its own line.
+			if afterPrefix = segment == ""; afterPrefix {
+				continue
+			}
+			names = append(names, segment)
+		} else if !prefixed || afterPrefix {
+			names = append(names, segment)
+		}
+		afterPrefix = false
+	}
+	return strings.Join(names, ", ")
+}
+
+// Splits a byline naming several authors. To keep names written as "Last, First" whole,
+// the byline is only split if all the names have several words.
+func splitByline(byline string) []string {
+	var names = bylineAuthorSeparators.Split(byline, -1)
+	for _, name := range names {
+		if len(strings.Fields(name)) < 2 {
+			return []string{strings.TrimSpace(byline)}
+		}
+	}
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+	}
+	return names
+}
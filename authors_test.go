@@ -0,0 +1,112 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorsFromByline(t *testing.T) {
+
+	reader, err := New("<html><body></body></html>", "http://fakehost/test/page.html")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		byline string
+		want   []Author
+	}{
+		{"By Nathan Willis\n                                            March 25, 2015", []Author{{Name: "Nathan Willis"}}},
+		{"By GILLIAN MOHNEY\n                                March 11, 2015 3:46 PM", []Author{{Name: "GILLIAN MOHNEY"}}},
+		{"Dan Goodin - Apr 16, 2015 8:02 pm UTC", []Author{{Name: "Dan Goodin"}}},
+		{"by Lucas Nolan22 Dec 2016", []Author{{Name: "Lucas Nolan"}}},
+		{"Jane Doe25.03.2015", []Author{{Name: "Jane Doe"}}},
+		{"Alex Perry\n                        \n                        1 day ago", []Author{{Name: "Alex Perry"}}},
+		{"By\n\t\t\tScott Cunningham", []Author{{Name: "Scott Cunningham"}}},
+		{"Par Sébastien Farcis", []Author{{Name: "Sébastien Farcis"}}},
+		{"Von Jane Doe, 25.03.2015", []Author{{Name: "Jane Doe"}}},
+		{"Nicolas Perriault —", []Author{{Name: "Nicolas Perriault"}}},
+		{"By Brenda  Goodman, MA\n                                                WebMD Health News", []Author{{Name: "Brenda Goodman, MA"}}},
+		{"Written by Rob Ewaschuk\n                            Edited by Betsy Beyer", []Author{{Name: "Rob Ewaschuk"}}},
+		{"Alexandre Hervaud, Jérémy Piette", []Author{{Name: "Alexandre Hervaud"}, {Name: "Jérémy Piette"}}},
+		{"By Jane Doe and Richard Roe", []Author{{Name: "Jane Doe"}, {Name: "Richard Roe"}}},
+		{"Jong, Michiel de", []Author{{Name: "Jong, Michiel de"}}},
+		{"Mac & i", []Author{{Name: "Mac & i"}}},
+		{"Martin Untersinger (avec Damien Leloup et Morgane Tual)", []Author{{Name: "Martin Untersinger"}}},
+		{"Bradley M. Kuhn (http://ebb.org/bkuhn/)", []Author{{Name: "Bradley M. Kuhn", URL: "http://ebb.org/bkuhn/"}}},
+		{"Jane Doe <jane@fakehost.com>", []Author{{Name: "Jane Doe", Email: "jane@fakehost.com"}}},
+		{"April 28, 2019 at 6:01 am Updated April 29, 2019 at 3:33 pm", nil},
+		{"", nil},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, reader.authorsFromByline(tc.byline), tc.byline)
+	}
+}
+
+func TestMergeAuthors(t *testing.T) {
+	var merged = mergeAuthors(
+		nil,
+		[]Author{{Name: "Jane Doe"}, {Name: "Richard Roe"}, {Name: "jane doe"}},
+		[]Author{{Name: "Richard Roe", URL: "http://fakehost/richard"}, {Name: "Someone Else"}},
+		[]Author{{Email: "jane@fakehost.com"}},
+	)
+	assert.Equal(t, []Author{{Name: "Jane Doe"}, {Name: "Richard Roe", URL: "http://fakehost/richard"}}, merged)
+
+	// An author only known by a link completes the only author
+	merged = mergeAuthors([]Author{{URL: "http://fakehost/jane"}}, []Author{{Name: "Jane Doe"}})
+	assert.Equal(t, []Author{{Name: "Jane Doe", URL: "http://fakehost/jane"}}, merged)
+
+	// or is kept if no author is named
+	merged = mergeAuthors([]Author{{URL: "http://fakehost/jane"}}, nil)
+	assert.Equal(t, []Author{{URL: "http://fakehost/jane"}}, merged)
+}
+
+func TestAuthors(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	var paragraphs = strings.Repeat("<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>", 5)
+
+	var parse = func(t *testing.T, head, body string) *Result {
+		reader, err := New("<html><head>"+head+"</head><body>"+body+"</body></html>", uri)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should extract the authors from JSON-LD", func(t *testing.T) {
+		var result = parse(t, `<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","author":[`+
+			`{"@type":"Person","name":"Jane Doe","url":"/authors/jane","email":"mailto:jane@fakehost.com"},`+
+			`{"@type":"Person","name":"Richard Roe"}]}</script>`,
+			`<article><p class="byline">By <a rel="author" href="/authors/richard">Richard Roe</a></p>`+paragraphs+`</article>`)
+		assert.Equal(t, []Author{
+			{Name: "Jane Doe", URL: "http://fakehost/authors/jane", Email: "jane@fakehost.com"},
+			{Name: "Richard Roe", URL: "http://fakehost/authors/richard"},
+		}, result.Authors)
+		assert.Equal(t, "Jane Doe, Richard Roe", result.Byline)
+	})
+
+	t.Run("should extract the authors from meta tags", func(t *testing.T) {
+		var result = parse(t, `<meta name="author" content="Jane Doe" />`+
+			`<meta property="article:author" content="https://www.facebook.com/janedoe" />`,
+			`<article>`+paragraphs+`</article>`)
+		assert.Equal(t, []Author{{Name: "Jane Doe", URL: "https://www.facebook.com/janedoe"}}, result.Authors)
+	})
+
+	t.Run("should extract the authors from the byline", func(t *testing.T) {
+		var result = parse(t, `<meta name="twitter:creator" content="@janedoe" />`,
+			`<article><p class="byline">By <a rel="author" href="/authors/jane">Jane Doe</a> and Richard Roe<br />`+"\n"+`March 25, 2015</p>`+paragraphs+`</article>`)
+		assert.Equal(t, []Author{
+			{Name: "Jane Doe", URL: "http://fakehost/authors/jane"},
+			{Name: "Richard Roe"},
+		}, result.Authors)
+	})
+
+	t.Run("should fall back to twitter:creator", func(t *testing.T) {
+		var result = parse(t, `<meta name="twitter:creator" content="@janedoe" />`, `<article>`+paragraphs+`</article>`)
+		assert.Equal(t, []Author{{URL: "https://twitter.com/janedoe", Handle: "@janedoe"}}, result.Authors)
+		assert.Equal(t, "", result.Byline)
+	})
+}
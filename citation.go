@@ -0,0 +1,187 @@
+package readability
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Citation holds the bibliographic metadata of a scholarly article, as found in the
+// Highwire Press (citation_*) and Dublin Core meta tags or in a JSON-LD ScholarlyArticle.
+type Citation struct {
+	// article title
+	Title string
+	// authors, in the order they are declared
+	Authors []string
+	// DOI, without any "doi:" or resolver prefix, e.g. "10.1000/xyz123"
+	DOI string
+	// absolute URL of the full text in PDF
+	PDFURL string
+	// title of the journal the article is published in
+	JournalTitle string
+	// publisher of the article
+	Publisher string
+	// publication date, as found in the metadata
+	PublicationDate string
+	Volume          string
+	Issue           string
+	FirstPage       string
+	LastPage        string
+}
+
+// Builds the citation of a scholarly article from the meta tag values, the repeated
+// author meta tags and the JSON-LD article. Returns nil if the page is not a scholarly article,
+// i.e. if it has neither citation_* meta tags nor a JSON-LD ScholarlyArticle.
+func (r *Readability) getCitation(values map[string]string, authors map[string][]string, jsonld map[string]interface{}) *Citation {
+	if !isScholarlyArticle(jsonld) {
+		jsonld = nil
+	}
+	if jsonld == nil && !hasCitationValues(values) {
+		return nil
+	}
+
+	var periodical = jsonLdPeriodical(jsonld)
+	var publisher string
+	if names := jsonLdNames(jsonld["publisher"]); len(names) != 0 {
+		publisher = names[0]
+	}
+
+	// Dublin Core tags are also used by news sites,
+	// so they only serve as a fallback for the Highwire and JSON-LD data.
+	var citation = &Citation{
+		Title: anyOf(values["citation_title"],
+			jsonLdString(jsonld["headline"]),
+			jsonLdString(jsonld["name"]),
+			values["dc:title"],
+			values["dcterm:title"]),
+		Authors: anyList(authors["citation_author"],
+			jsonLdNames(jsonld["author"]),
+			authors["dc:creator"],
+			authors["dcterm:creator"]),
+		DOI: anyOf(normalizeDOI(values["citation_doi"]),
+			jsonLdDOI(jsonld["identifier"]),
+			jsonLdDOI(jsonld["sameAs"]),
+			jsonLdDOI(jsonld["@id"]),
+			normalizeDOI(values["dc:identifier"]),
+			normalizeDOI(values["dcterm:identifier"])),
+		PDFURL: values["citation_pdf_url"],
+		JournalTitle: anyOf(values["citation_journal_title"],
+			periodical["name"]),
+		Publisher: anyOf(values["citation_publisher"],
+			publisher,
+			values["dc:publisher"],
+			values["dcterm:publisher"]),
+		PublicationDate: anyOf(values["citation_publication_date"],
+			values["citation_date"],
+			jsonLdString(jsonld["datePublished"]),
+			values["dc:date:issued"],
+			values["dcterm:date:issued"],
+			values["dc:date"],
+			values["dcterm:date"]),
+		Volume: anyOf(values["citation_volume"],
+			periodical["volumeNumber"]),
+		Issue: anyOf(values["citation_issue"],
+			periodical["issueNumber"]),
+		FirstPage: anyOf(values["citation_firstpage"],
+			jsonLdString(jsonld["pageStart"])),
+		LastPage: anyOf(values["citation_lastpage"],
+			jsonLdString(jsonld["pageEnd"])),
+	}
+
+	if citation.PDFURL != "" {
+		citation.PDFURL = r.toAbsoluteURI(r.unescapeHtmlEntities(citation.PDFURL))
+	}
+
+	return citation
+}
+
+// Reorders an author name given as "Last, First", as in the citation_author meta tags,
+// to "First Last", so that the names can be joined in a byline.
+func citationAuthorName(name string) string {
+	var parts = strings.Split(name, ",")
+	if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+		return name
+	}
+	return strings.TrimSpace(parts[1]) + " " + strings.TrimSpace(parts[0])
+}
+
+// Checks whether any of the Highwire Press citation_* meta tags was found.
+func hasCitationValues(values map[string]string) bool {
+	for name := range values {
+		if strings.HasPrefix(name, "citation_") {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks whether the given JSON-LD object is a ScholarlyArticle or one of its subtypes.
+func isScholarlyArticle(jsonld map[string]interface{}) bool {
+	var types []interface{}
+	switch t := jsonld["@type"].(type) {
+	case string:
+		types = []interface{}{t}
+	case []interface{}:
+		types = t
+	}
+	for _, t := range types {
+		if t, ok := t.(string); ok && strings.HasSuffix(t, "ScholarlyArticle") {
+			return true
+		}
+	}
+	return false
+}
+
+// Collects the journal name and the volume and issue numbers from the
+// chain of PublicationIssue, PublicationVolume and Periodical objects
+// the given JSON-LD article is part of.
+func jsonLdPeriodical(jsonld map[string]interface{}) map[string]string {
+	var periodical = make(map[string]string)
+	var part, _ = jsonld["isPartOf"].(map[string]interface{})
+	for depth := 0; part != nil && depth < jsonLdMaxDepth; depth++ {
+		for _, key := range []string{"issueNumber", "volumeNumber", "name"} {
+			if _, found := periodical[key]; !found {
+				if value := jsonLdString(part[key]); value != "" {
+					periodical[key] = value
+				} else if value, ok := part[key].(float64); ok {
+					periodical[key] = strconv.FormatFloat(value, 'f', -1, 64)
+				}
+			}
+		}
+		part, _ = part["isPartOf"].(map[string]interface{})
+	}
+	return periodical
+}
+
+// Returns the first DOI found in the given JSON-LD identifier, which can be
+// a string, a PropertyValue or a list of those.
+func jsonLdDOI(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return normalizeDOI(v)
+	case map[string]interface{}:
+		return anyOf(normalizeDOI(jsonLdString(v["value"])), normalizeDOI(jsonLdString(v["@id"])), normalizeDOI(jsonLdString(v["url"])))
+	case []interface{}:
+		for _, el := range v {
+			if doi := jsonLdDOI(el); doi != "" {
+				return doi
+			}
+		}
+	}
+	return ""
+}
+
+// Normalizes a DOI given as "10.1000/xyz123", "doi:10.1000/xyz123" or
+// "https://doi.org/10.1000/xyz123" to its bare form.
+// Returns an empty string if the value is not a DOI.
+func normalizeDOI(s string) string {
+	s = strings.TrimSpace(s)
+	// DOIs in resolver URLs can be percent-encoded.
+	if unescaped, err := url.PathUnescape(s); err == nil {
+		s = unescaped
+	}
+	if submatches := doiPattern.FindStringSubmatch(s); submatches != nil {
+		return submatches[1]
+	}
+	return ""
+}
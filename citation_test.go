@@ -0,0 +1,122 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCitation(t *testing.T) {
+
+	const uri = "http://fakehost/journal/article/42"
+
+	var article = "<body><article>" + strings.Repeat("<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>", 5) + "</article></body>"
+
+	var parse = func(t *testing.T, head string) *Result {
+		reader, err := New("<html><head><title>Journal of Things | A page title</title>"+head+"</head>"+article+"</html>", uri)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should extract the citation from Highwire Press meta tags", func(t *testing.T) {
+		var result = parse(t, `<meta name="citation_title" content="On the Origin of Things">`+
+			`<meta name="citation_author" content="Doe, Jane">`+
+			`<meta name="citation_author" content="Roe, Richard">`+
+			`<meta name="citation_author" content="O&#39;Brien, Anne">`+
+			`<meta name="citation_doi" content="doi:10.1000/xyz.123">`+
+			`<meta name="citation_pdf_url" content="/journal/article/42.pdf">`+
+			`<meta name="citation_journal_title" content="Journal of Things">`+
+			`<meta name="citation_publisher" content="Things &amp; Co.">`+
+			`<meta name="citation_publication_date" content="2021/03/25">`+
+			`<meta name="citation_volume" content="7">`+
+			`<meta name="citation_issue" content="2">`+
+			`<meta name="citation_firstpage" content="101">`+
+			`<meta name="citation_lastpage" content="118">`+
+			`<meta property="og:title" content="Open Graph title">`+
+			`<meta name="author" content="Webmaster">`)
+		assert.Equal(t, &Citation{
+			Title:           "On the Origin of Things",
+			Authors:         []string{"Doe, Jane", "Roe, Richard", "O'Brien, Anne"},
+			DOI:             "10.1000/xyz.123",
+			PDFURL:          "http://fakehost/journal/article/42.pdf",
+			JournalTitle:    "Journal of Things",
+			Publisher:       "Things & Co.",
+			PublicationDate: "2021/03/25",
+			Volume:          "7",
+			Issue:           "2",
+			FirstPage:       "101",
+			LastPage:        "118",
+		}, result.Citation)
+		assert.Equal(t, "On the Origin of Things", result.Title)
+		assert.Equal(t, "Jane Doe, Richard Roe, Anne O'Brien", result.Byline)
+		assert.Equal(t, []Author{{Name: "Jane Doe"}, {Name: "Richard Roe"}, {Name: "Anne O'Brien"}}, result.Authors)
+		assert.Equal(t, "2021/03/25", result.PublishedTime)
+		assert.Equal(t, 2021, result.Published.Year())
+	})
+
+	t.Run("should extract the citation from a JSON-LD ScholarlyArticle", func(t *testing.T) {
+		var result = parse(t, `<script type="application/ld+json">{"@context":"https://schema.org","@type":"ScholarlyArticle",`+
+			`"headline":"On the Origin of Things","author":[{"@type":"Person","name":"Jane Doe"},{"@type":"Person","name":"Richard Roe"}],`+
+			`"identifier":[{"@type":"PropertyValue","propertyID":"ISSN","value":"1234-5678"},{"@type":"PropertyValue","propertyID":"DOI","value":"https://doi.org/10.1000%2Fxyz.123"}],`+
+			`"datePublished":"2021-03-25","pageStart":"101","pageEnd":"118",`+
+			`"isPartOf":{"@type":"PublicationIssue","issueNumber":2,"isPartOf":{"@type":"PublicationVolume","volumeNumber":"7","isPartOf":{"@type":"Periodical","name":"Journal of Things"}}}}</script>`)
+		assert.Equal(t, &Citation{
+			Title:           "On the Origin of Things",
+			Authors:         []string{"Jane Doe", "Richard Roe"},
+			DOI:             "10.1000/xyz.123",
+			JournalTitle:    "Journal of Things",
+			PublicationDate: "2021-03-25",
+			Volume:          "7",
+			Issue:           "2",
+			FirstPage:       "101",
+			LastPage:        "118",
+		}, result.Citation)
+		assert.Equal(t, "Jane Doe, Richard Roe", result.Byline)
+	})
+
+	t.Run("should fall back to Dublin Core meta tags on scholarly pages", func(t *testing.T) {
+		var result = parse(t, `<meta name="citation_journal_title" content="Journal of Things">`+
+			`<meta name="DC.title" content="On the Origin of Things">`+
+			`<meta name="DC.creator" content="Jane Doe">`+
+			`<meta name="DC.creator" content="Richard Roe">`+
+			`<meta name="DC.identifier" content="https://dx.doi.org/10.1000/XYZ.123">`+
+			`<meta name="DC.date.issued" content="2021-03-25">`)
+		assert.Equal(t, "On the Origin of Things", result.Citation.Title)
+		assert.Equal(t, []string{"Jane Doe", "Richard Roe"}, result.Citation.Authors)
+		assert.Equal(t, "10.1000/XYZ.123", result.Citation.DOI)
+		assert.Equal(t, "2021-03-25", result.Citation.PublicationDate)
+		assert.Equal(t, "Jane Doe, Richard Roe", result.Byline)
+	})
+
+	t.Run("should not extract a citation from other pages", func(t *testing.T) {
+		var result = parse(t, `<meta name="DC.title" content="Dublin Core title">`+
+			`<meta name="DC.creator" content="Jane Doe">`+
+			`<meta name="DC.creator" content="Richard Roe">`+
+			`<script type="application/ld+json">{"@context":"https://schema.org","@type":"NewsArticle","headline":"News"}</script>`)
+		assert.Nil(t, result.Citation)
+		assert.Equal(t, "News", result.Title)
+		assert.Equal(t, "Richard Roe", result.Byline)
+	})
+}
+
+func TestNormalizeDOI(t *testing.T) {
+	testCases := []struct {
+		doi  string
+		want string
+	}{
+		{"10.1000/xyz123", "10.1000/xyz123"},
+		{" doi:10.1000/xyz123 ", "10.1000/xyz123"},
+		{"DOI: 10.1000/xyz123", "10.1000/xyz123"},
+		{"https://doi.org/10.1000/xyz123", "10.1000/xyz123"},
+		{"http://dx.doi.org/10.1000%2Fxyz%3C123%3E", "10.1000/xyz<123>"},
+		{"10.1000/", ""},
+		{"urn:ietf:id:dejong-remotestorage", ""},
+		{"https://example.com/10.1000/xyz123", ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, normalizeDOI(tc.doi), tc.doi)
+	}
+}
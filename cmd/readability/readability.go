@@ -1,19 +1,25 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"os"
 
 	"github.com/giulianopz/go-readability"
+	"github.com/giulianopz/go-readability/epub"
 )
 
 var (
 	output  string
 	verbose bool
+	pages   int
+	width   int
+	raw     bool
+	out     string
+	theme   string
 )
 
 func handle(err error) {
@@ -29,36 +35,88 @@ func exit(msg string) {
 
 func main() {
 
-	flag.StringVar(&output, "output", "text", "the result output format: 'text' or 'html'")
-	flag.StringVar(&output, "o", "text", "the result output format: 'text' or 'html'")
+	flag.StringVar(&output, "output", "text", "the result output format: 'text', 'html', 'markdown', 'gemtext', 'reader' or 'epub'")
+	flag.StringVar(&output, "o", "text", "the result output format: 'text', 'html', 'markdown', 'gemtext', 'reader' or 'epub'")
 	flag.BoolVar(&verbose, "verbose", false, "enable logs")
 	flag.BoolVar(&verbose, "v", false, "enable logs")
+	flag.IntVar(&width, "width", 0, "the column at which the 'text' output is wrapped, 0 to not wrap it")
+	flag.BoolVar(&raw, "raw", false, "print the raw text content of the article as 'text' output, without its structure")
+	flag.StringVar(&out, "out", "", "the file the 'epub' output is written to")
+	flag.StringVar(&theme, "theme", "light", "the theme of the 'reader' output: 'light', 'dark' or 'sepia'")
+	flag.IntVar(&pages, "pages", 1, "the maximum number of pages of a multi-page article to fetch")
 	flag.Parse()
 
-	if !verbose {
-		slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	var logger *slog.Logger
+	var opts []readability.Option
+	if verbose {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		opts = append(opts, readability.Logger(logger))
 	}
 
-	url := flag.Arg(0)
-	if url == "" {
+	switch output {
+	case "markdown":
+		opts = append(opts, readability.Serializer(readability.Markdown))
+	case "gemtext":
+		opts = append(opts, readability.Serializer(readability.Gemtext))
+	case "reader":
+		// The page is opened in a browser.
+		opts = append(opts, readability.Sanitize(readability.DefaultPolicy()))
+	case "text":
+		if !raw {
+			opts = append(opts, readability.TextSerializer(readability.TextRenderer{Width: width}.Render))
+		}
+	}
+	if pages > 1 {
+		opts = append(opts, readability.PageFetcher(&readability.HTTPFetcher{}), readability.MaxPages(pages))
+	}
+
+	if flag.NArg() == 0 {
 		exit("missing url")
 	}
 
-	resp, err := http.Get(url)
-	handle(err)
+	if output == "epub" {
+		// Every article given is a chapter of the publication.
+		if out == "" {
+			exit("missing -out file")
+		}
+		var articles []*readability.Result
+		for _, url := range flag.Args() {
+			articles = append(articles, parse(url, opts))
+		}
+		f, err := os.Create(out)
+		handle(err)
+		err = epub.Write(context.Background(), f, articles, epub.Logger(logger))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			// A partial publication is not a valid EPUB.
+			_ = os.Remove(out)
+			exit(err.Error())
+		}
+		return
+	}
+
+	res := parse(flag.Arg(0), opts)
+	switch output {
+	case "reader":
+		fmt.Print(readability.RenderReaderView(res, readability.Theme(theme)))
+	case "html", "markdown", "gemtext":
+		fmt.Print(res.HTMLContent)
+	default:
+		fmt.Print(res.TextContent)
+	}
+}
 
-	bs, err := io.ReadAll(resp.Body)
+func parse(url string, opts []readability.Option) *readability.Result {
+	resp, err := http.Get(url)
 	handle(err)
+	defer resp.Body.Close()
 
-	parser, err := readability.New(string(bs), url)
+	parser, err := readability.NewFromReader(resp.Body, resp.Request.URL, resp.Header.Get("Content-Type"), opts...)
 	handle(err)
 
 	res, err := parser.Parse()
 	handle(err)
-
-	if output == "html" {
-		fmt.Print(res.HTMLContent)
-	} else {
-		fmt.Print(res.TextContent)
-	}
+	return res
 }
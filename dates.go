@@ -0,0 +1,99 @@
+package readability
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Layouts tried, in order, when parsing dates found in metadata.
+// Dates without a timezone are assumed to be in UTC.
+var dateLayouts = []string{
+	// ISO 8601 / RFC 3339 and their common variations
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z0700",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"20060102",
+	// RFC 1123, RFC 850 and friends
+	time.RFC1123,
+	time.RFC1123Z,
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	time.RFC850,
+	time.RFC822,
+	time.RFC822Z,
+	time.ANSIC,
+	time.UnixDate,
+	// common locale formats
+	"January 2, 2006 15:04",
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006",
+	"Jan 2, 2006 15:04",
+	"Jan 2, 2006 3:04 PM",
+	"Jan 2, 2006",
+	"2 January 2006 15:04",
+	"2 January 2006",
+	"2 Jan 2006 15:04",
+	"2 Jan 2006",
+	"Monday, January 2, 2006",
+	"Monday, 2 January 2006",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+	"02.01.2006 15:04",
+	"02.01.2006",
+	"2.1.2006",
+	// Slashed dates are read month first, as in the US: a day-first layout would
+	// give ambiguous dates, e.g. 03/04/2020, two readings.
+	"01/02/2006 15:04",
+	"01/02/2006",
+}
+
+// Parses a date in any of the supported layouts.
+// Returns false if the date cannot be parsed.
+func parseDate(s string) (time.Time, bool) {
+	s = normalize.ReplaceAllString(strings.TrimSpace(s), " ")
+	if s == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Looks for a date in the path of the given URL, as in /2015/03/25/ or /2015-03-25/.
+func dateFromURL(uri string) (time.Time, bool) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return time.Time{}, false
+	}
+	submatches := urlDatePattern.FindStringSubmatch(u.Path)
+	if submatches == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("2006-01-02", submatches[1]+"-"+submatches[2]+"-"+submatches[3])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// Looks for the first parseable <time datetime> element inside the given node.
+func (r *Readability) dateFromTimeElements(n *Node) (time.Time, bool) {
+	for _, timeElement := range n.getElementsByTagName("time") {
+		if t, ok := parseDate(timeElement.GetAttribute("datetime")); ok {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,93 @@
+package readability
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+
+	testCases := []struct {
+		input string
+		want  time.Time
+	}{
+		{input: "2015-03-17T16:27:40.294Z", want: time.Date(2015, 3, 17, 16, 27, 40, 294000000, time.UTC)},
+		{input: "2013-09-11T10:00:00-04:00", want: time.Date(2013, 9, 11, 14, 0, 0, 0, time.UTC)},
+		{input: "2021-11-01T10:52:50+0100", want: time.Date(2021, 11, 1, 9, 52, 50, 0, time.UTC)},
+		{input: "2015-04-30T07:19:58", want: time.Date(2015, 4, 30, 7, 19, 58, 0, time.UTC)},
+		{input: "2017-11-24T18:42:20.314667", want: time.Date(2017, 11, 24, 18, 42, 20, 314667000, time.UTC)},
+		{input: "2018-04-05T06:00", want: time.Date(2018, 4, 5, 6, 0, 0, 0, time.UTC)},
+		{input: "2017-11-03 03:01:00.000000", want: time.Date(2017, 11, 3, 3, 1, 0, 0, time.UTC)},
+		{input: "2019-04-28 06:01:07", want: time.Date(2019, 4, 28, 6, 1, 7, 0, time.UTC)},
+		{input: " 2020-09-21 ", want: time.Date(2020, 9, 21, 0, 0, 0, 0, time.UTC)},
+		{input: "Wed, 25 Mar 2015 10:00:00 GMT", want: time.Date(2015, 3, 25, 10, 0, 0, 0, time.UTC)},
+		{input: "Wed, 25 Mar 2015 10:00:00 +0000", want: time.Date(2015, 3, 25, 10, 0, 0, 0, time.UTC)},
+		{input: "March 25, 2015", want: time.Date(2015, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{input: "25 March 2015", want: time.Date(2015, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{input: "Mar 25, 2015 3:04 PM", want: time.Date(2015, 3, 25, 15, 4, 0, 0, time.UTC)},
+		{input: "25.03.2015", want: time.Date(2015, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{input: "2015/03/25", want: time.Date(2015, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{input: "03/04/2020", want: time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		got, ok := parseDate(tc.input)
+		if assert.True(t, ok, tc.input) {
+			assert.True(t, tc.want.Equal(got), "got %v want %v for %q", got, tc.want, tc.input)
+		}
+	}
+
+	for _, input := range []string{"", "yesterday", "2015-13-45", "25/03/2015", "25-03-2015"} {
+		_, ok := parseDate(input)
+		assert.False(t, ok, input)
+	}
+}
+
+func TestDateFallbacks(t *testing.T) {
+
+	var paragraph = "<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. " +
+		"Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat.</p>"
+
+	var parse = func(t *testing.T, source, uri string) *Result {
+		reader, err := New(source, uri)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should parse published and modified times from the metadata", func(t *testing.T) {
+		var result = parse(t, `<html><head>`+
+			`<meta property="article:published_time" content="2015-03-25T10:00:00Z">`+
+			`<meta property="article:modified_time" content="2015-03-26T10:00:00Z">`+
+			`</head><body><article>`+paragraph+paragraph+paragraph+`</article></body></html>`, "http://fakehost/test/page.html")
+		assert.Equal(t, "2015-03-25T10:00:00Z", result.PublishedTime)
+		assert.Equal(t, time.Date(2015, 3, 25, 10, 0, 0, 0, time.UTC), result.Published)
+		assert.Equal(t, "2015-03-26T10:00:00Z", result.ModifiedTime)
+		assert.Equal(t, time.Date(2015, 3, 26, 10, 0, 0, 0, time.UTC), result.Modified)
+	})
+
+	t.Run("should parse dateModified from JSON-LD", func(t *testing.T) {
+		var result = parse(t, `<html><head><script type="application/ld+json">`+
+			`{"@context":"https://schema.org","@type":"NewsArticle","datePublished":"2015-03-25","dateModified":"2015-03-27"}`+
+			`</script></head><body><article>`+paragraph+paragraph+paragraph+`</article></body></html>`, "http://fakehost/test/page.html")
+		assert.Equal(t, time.Date(2015, 3, 25, 0, 0, 0, 0, time.UTC), result.Published)
+		assert.Equal(t, time.Date(2015, 3, 27, 0, 0, 0, 0, time.UTC), result.Modified)
+	})
+
+	t.Run("should fall back to the time elements of the article", func(t *testing.T) {
+		var result = parse(t, `<html><body><article>`+paragraph+
+			`<p>Posted on <time datetime="2015-03-25T08:30:00+01:00">March 25</time> by the staff of the website.</p>`+
+			paragraph+paragraph+`</article></body></html>`, "http://fakehost/2010/01/01/page.html")
+		assert.Empty(t, result.PublishedTime)
+		assert.Equal(t, time.Date(2015, 3, 25, 7, 30, 0, 0, time.UTC), result.Published.UTC())
+	})
+
+	t.Run("should fall back to the date in the URL path", func(t *testing.T) {
+		var result = parse(t, `<html><body><article>`+paragraph+paragraph+paragraph+`</article></body></html>`,
+			"http://fakehost/2015/03/25/page.html")
+		assert.Equal(t, time.Date(2015, 3, 25, 0, 0, 0, 0, time.UTC), result.Published)
+	})
+}
@@ -380,6 +380,17 @@ type Node struct {
 	DocumentElement      *Node
 	ReadabilityNode      *readabilityNode
 	ReadabilityDataTable *readabilityDataTable
+	// language of a code block, recorded before its language-* class is removed
+	codeLanguage string
+	// logger inherited from the parser or the document which created the node
+	logger *slog.Logger
+}
+
+func (n *Node) log() *slog.Logger {
+	if n.logger == nil {
+		return discardLogger
+	}
+	return n.logger
 }
 
 type readabilityDataTable struct {
@@ -390,6 +401,16 @@ type readabilityNode struct {
 	ContentScore float64
 }
 
+// IsElement reports whether the node is an element, e.g. a <p>.
+func (n *Node) IsElement() bool {
+	return n.NodeType == elementNode
+}
+
+// IsText reports whether the node is a text node.
+func (n *Node) IsText() bool {
+	return n.NodeType == textNode
+}
+
 func (n *Node) FirstChild() *Node {
 	if len(n.ChildNodes) == 0 {
 		return nil
@@ -421,7 +442,7 @@ func (n *Node) LastChild() *Node {
 func (n *Node) AppendChild(child *Node) {
 	if child.ParentNode != nil {
 		if _, err := child.ParentNode.RemoveChild(child); err != nil {
-			slog.Error("cannot remove child", slog.String("err", err.Error()))
+			n.log().Error("cannot remove child", slog.String("err", err.Error()))
 		}
 	}
 
@@ -490,7 +511,7 @@ func (n *Node) ReplaceChild(newNode, oldNode *Node) *Node {
 		// This will take care of updating the new node if it was somewhere else before:
 		if newNode.ParentNode != nil {
 			if _, err := newNode.ParentNode.RemoveChild(newNode); err != nil {
-				slog.Error("cannot remove child", slog.String("err", err.Error()))
+				n.log().Error("cannot remove child", slog.String("err", err.Error()))
 			}
 		}
 		childNodes[childIndex] = newNode
@@ -630,7 +651,7 @@ func (t *Node) getTextContentFromTextNode() string {
 	if t.textContent == "" {
 		decoded, err := decodeHTML(t.GetInnerHTML())
 		if err != nil {
-			slog.Error("cannot decode inner html", "err", err)
+			t.log().Error("cannot decode inner html", "err", err)
 			return ""
 		}
 		t.textContent = decoded
@@ -685,11 +706,14 @@ func (n *Node) GetElementById(id string) *Node {
 }
 
 func (d *Node) createElementNode(tag string) *Node {
-	return newElement(tag)
+	node := newElement(tag)
+	node.logger = d.logger
+	return node
 }
 
 func (d *Node) createTextNode(text string) *Node {
 	node := newText()
+	node.logger = d.logger
 	node.SetTextContent(text)
 	return node
 }
@@ -751,6 +775,15 @@ func (n *Node) GetAttribute(name string) string {
 	return ""
 }
 
+// GetAttributeNames returns the names of the attributes of the element, in order.
+func (n *Node) GetAttributeNames() []string {
+	var names = make([]string, 0, len(n.Attributes))
+	for _, attr := range n.Attributes {
+		names = append(names, attr.name)
+	}
+	return names
+}
+
 func (n *Node) GetAttributeByIndex(idx int) *attribute {
 	return n.Attributes[idx]
 }
@@ -940,7 +973,7 @@ func (n *Node) SetInnerHTML(html string) {
 	if n.NodeType == textNode {
 		n.setInnerHTMLFromTextNode(html)
 	} else if n.NodeType == elementNode {
-		var parser = newDOMParser()
+		var parser = newDOMParser(Logger(n.logger))
 		var node = parser.parse(html, "")
 		for i := len(n.ChildNodes) - 1; i >= 0; i-- {
 			n.ChildNodes[i].ParentNode = nil
@@ -967,6 +1000,7 @@ func (n *Node) SetTextContent(text string) {
 		}
 
 		var t = newText()
+		t.logger = n.logger
 		n.ChildNodes = []*Node{t}
 		n.Children = []*Node{}
 		t.textContent = text
@@ -1036,6 +1070,7 @@ func (p *domParser) makeElementNode() *Node {
 	}
 
 	var node = newElement(tag)
+	node.logger = p.options.logger
 
 	for _, a := range token.Attr {
 		node.SetAttribute(a.Key, a.Val)
@@ -1060,6 +1095,7 @@ loop:
 			n.AppendChild(&Node{
 				nodeName: "#documentType",
 				NodeType: documentTypeNode,
+				logger:   p.options.logger,
 			})
 
 		case html.CommentToken:
@@ -1068,6 +1104,7 @@ loop:
 		case html.TextToken:
 			{
 				textNode := newText()
+				textNode.logger = p.options.logger
 
 				data := p.z.Raw()
 				c, _ := utf8.DecodeRune(data)
@@ -1084,7 +1121,7 @@ loop:
 						n.AppendChild(textNode)
 					}
 				} else {
-					slog.Debug("unhandled text", slog.String("txt", txt))
+					p.options.logger.Debug("unhandled text", slog.String("txt", txt))
 				}
 			}
 
@@ -1092,7 +1129,7 @@ loop:
 			{
 				node := p.makeElementNode()
 				if node == nil {
-					slog.Debug("cannot create element node")
+					p.options.logger.Debug("cannot create element node")
 					break loop
 				}
 
@@ -1134,6 +1171,7 @@ func (p *domParser) parse(htmlSrc, url string) *Node {
 	p.html = htmlSrc
 	p.z = html.NewTokenizer(strings.NewReader(htmlSrc))
 	p.doc = newDocument(url)
+	p.doc.logger = p.options.logger
 	p.z.AllowCDATA(true)
 	p.readNode(p.doc)
 
@@ -1145,7 +1183,7 @@ func (p *domParser) parse(htmlSrc, url string) *Node {
 			var child = p.doc.ChildNodes[i]
 			if child != p.doc.DocumentElement {
 				if _, err := p.doc.RemoveChild(child); err != nil {
-					slog.Error("cannot remove child", slog.String("err", err.Error()))
+					p.options.logger.Error("cannot remove child", slog.String("err", err.Error()))
 				}
 			}
 			i--
@@ -0,0 +1,272 @@
+// Package epub writes articles extracted by Readability as EPUB 3 publications,
+// e.g. to read them later on an e-reader.
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/giulianopz/go-readability"
+)
+
+// ErrNoArticles is returned by Write when no article is given.
+var ErrNoArticles = errors.New("epub: no article to write")
+
+const (
+	// maximum size of an embedded image, in bytes
+	maxImageSize = 10 << 20
+	// format of the dates of the package document
+	dateFormat = "2006-01-02T15:04:05Z"
+)
+
+// Extensions of the image media types an EPUB reader must support.
+var imageExtensions = map[string]string{
+	"image/gif":     ".gif",
+	"image/jpeg":    ".jpg",
+	"image/png":     ".png",
+	"image/svg+xml": ".svg",
+	"image/webp":    ".webp",
+}
+
+// Write writes the given articles to w as an EPUB 3 publication, one chapter per
+// article, in order. The table of contents lists the articles and their headings.
+// The images of the articles are downloaded with the ImageFetcher and embedded in
+// the publication.
+func Write(ctx context.Context, w io.Writer, articles []*readability.Result, opts ...Option) error {
+	if len(articles) == 0 {
+		return ErrNoArticles
+	}
+	var options = defaultOpts()
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	var b = &book{
+		options: options,
+		zip:     zip.NewWriter(w),
+		images:  make(map[string]string),
+	}
+	if err := b.writeContainer(); err != nil {
+		return err
+	}
+	var chapters []*chapter
+	for i, article := range articles {
+		if article.Content == nil {
+			return fmt.Errorf("epub: article %d has no content", i+1)
+		}
+		var c = &chapter{
+			book:    b,
+			article: article,
+			ID:      "chapter-" + strconv.Itoa(i+1),
+			Title:   anyOf(strings.TrimSpace(article.Title), "Chapter "+strconv.Itoa(i+1)),
+			Byline:  strings.TrimSpace(article.Byline),
+			Lang:    article.Lang,
+			Dir:     article.Dir,
+			ids:     make(map[string]bool),
+		}
+		c.Href = c.ID + ".xhtml"
+		if err := c.render(ctx); err != nil {
+			return err
+		}
+		if err := b.writeTemplate(c.Href, chapterTemplate, c); err != nil {
+			return err
+		}
+		b.manifest = append(b.manifest, manifestItem{ID: c.ID, Href: c.Href, MediaType: "application/xhtml+xml"})
+		chapters = append(chapters, c)
+	}
+
+	var metadata = b.metadata(articles)
+	if err := b.writeTemplate("nav.xhtml", navTemplate, map[string]any{"Lang": metadata.Lang, "Chapters": chapters}); err != nil {
+		return err
+	}
+	if err := b.writeTemplate("package.opf", packageTemplate, map[string]any{"Metadata": metadata, "Manifest": b.manifest, "Chapters": chapters}); err != nil {
+		return err
+	}
+	return b.zip.Close()
+}
+
+// Publication being written.
+type book struct {
+	options *Options
+	zip     *zip.Writer
+	// paths of the embedded images by source URL, empty for those which cannot be embedded
+	images   map[string]string
+	manifest []manifestItem
+}
+
+// Resource of the publication, other than the navigation document.
+type manifestItem struct {
+	ID, Href, MediaType string
+}
+
+// Metadata of the publication.
+type metadata struct {
+	Identifier string
+	Title      string
+	Creators   []string
+	Lang       string
+	Date       string
+	Modified   string
+}
+
+// Writes the mimetype file, which must be the first one and be stored uncompressed,
+// and the container file pointing to the package document.
+func (b *book) writeContainer() error {
+	var mimetype = []byte("application/epub+zip")
+	w, err := b.zip.CreateRaw(&zip.FileHeader{
+		Name:               "mimetype",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(mimetype),
+		CompressedSize64:   uint64(len(mimetype)),
+		UncompressedSize64: uint64(len(mimetype)),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(mimetype); err != nil {
+		return err
+	}
+	w, err = b.zip.Create("META-INF/container.xml")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, containerXML)
+	return err
+}
+
+// Writes the given file of the publication, from the given template.
+func (b *book) writeTemplate(name string, tmpl *template.Template, data any) error {
+	w, err := b.zip.Create("EPUB/" + name)
+	if err != nil {
+		return err
+	}
+	return tmpl.Execute(w, data)
+}
+
+// Returns the metadata of the publication, from the options or from the articles.
+func (b *book) metadata(articles []*readability.Result) *metadata {
+	var m = &metadata{
+		Identifier: b.options.identifier,
+		Title:      b.options.title,
+		Lang:       b.options.lang,
+	}
+	var modified = b.options.modified
+	if modified.IsZero() {
+		modified = time.Now()
+	}
+	m.Modified = modified.UTC().Format(dateFormat)
+
+	var published time.Time
+	var hash = sha1.New()
+	for _, article := range articles {
+		for _, author := range article.Authors {
+			if name := strings.TrimSpace(anyOf(author.Name, author.Handle)); name != "" && !slices.Contains(m.Creators, name) {
+				m.Creators = append(m.Creators, name)
+			}
+		}
+		m.Lang = anyOf(m.Lang, article.Lang)
+		if article.Published.After(published) {
+			published = article.Published
+		}
+		fmt.Fprintf(hash, "%s\n%s\n", article.Title, article.CanonicalURL)
+	}
+	if m.Title == "" {
+		m.Title = "Articles"
+		if len(articles) == 1 {
+			m.Title = anyOf(strings.TrimSpace(articles[0].Title), m.Title)
+		}
+	}
+	m.Lang = anyOf(m.Lang, "und")
+	if !published.IsZero() {
+		m.Date = published.UTC().Format(dateFormat)
+	}
+	if m.Identifier == "" {
+		// Name-based UUID (version 5)
+		var sum = hash.Sum(nil)
+		sum[6] = sum[6]&0x0f | 0x50
+		sum[8] = sum[8]&0x3f | 0x80
+		m.Identifier = fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+	}
+	return m
+}
+
+// Embeds the image found at the given URL in the publication, once. Returns its
+// path relative to the chapters, or "" if it cannot be embedded.
+func (b *book) image(ctx context.Context, src string) string {
+	if href, ok := b.images[src]; ok || b.options.fetcher == nil {
+		return href
+	}
+	var href, err = b.embedImage(ctx, src)
+	if err != nil {
+		b.options.logger.Error("cannot embed image", "src", src, "err", err.Error())
+	}
+	b.images[src] = href
+	return href
+}
+
+func (b *book) embedImage(ctx context.Context, src string) (string, error) {
+	u, err := url.Parse(src)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("unsupported URL scheme %q", u.Scheme)
+	}
+	body, contentType, err := b.options.fetcher.Fetch(ctx, u)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, maxImageSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxImageSize {
+		return "", fmt.Errorf("image larger than %d bytes", maxImageSize)
+	}
+
+	var mediaType, _, _ = mime.ParseMediaType(contentType)
+	if _, ok := imageExtensions[mediaType]; !ok {
+		// Servers often send images as application/octet-stream.
+		mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+	}
+	var ext, ok = imageExtensions[mediaType]
+	if !ok {
+		return "", fmt.Errorf("unsupported media type %q", mediaType)
+	}
+
+	var id = "image-" + strconv.Itoa(len(b.images)+1)
+	var href = "images/" + id + ext
+	w, err := b.zip.Create("EPUB/" + href)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(w, bytes.NewReader(data)); err != nil {
+		return "", err
+	}
+	b.manifest = append(b.manifest, manifestItem{ID: id, Href: href, MediaType: mediaType})
+	return href, nil
+}
+
+func anyOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
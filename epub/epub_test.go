@@ -0,0 +1,146 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/giulianopz/go-readability"
+	"github.com/stretchr/testify/assert"
+)
+
+var paragraph = "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor. ", 4) + "</p>"
+
+// Serves a 1x1 PNG image at /fox.png, and nothing else.
+func newImageServer(t *testing.T) *httptest.Server {
+	var img bytes.Buffer
+	assert.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/fox.png" {
+			http.NotFound(w, req)
+			return
+		}
+		// The media type is sniffed from the content.
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(img.Bytes())
+	}))
+}
+
+func parse(t *testing.T, html, uri string) *readability.Result {
+	reader, err := readability.New(html, uri)
+	assert.NoError(t, err)
+	result, err := reader.Parse()
+	assert.NoError(t, err)
+	return result
+}
+
+// Returns the files of the given EPUB container by name, checking that the XML ones are well-formed.
+func readEPUB(t *testing.T, data []byte) ([]*zip.File, map[string]string) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+	var files = make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		files[f.Name] = string(content)
+
+		if strings.HasSuffix(f.Name, ".xml") || strings.HasSuffix(f.Name, ".xhtml") || strings.HasSuffix(f.Name, ".opf") {
+			var decoder = xml.NewDecoder(bytes.NewReader(content))
+			for {
+				if _, err := decoder.Token(); err != nil {
+					assert.ErrorIs(t, err, io.EOF, f.Name)
+					break
+				}
+			}
+		}
+	}
+	return archive.File, files
+}
+
+func TestWrite(t *testing.T) {
+
+	var server = newImageServer(t)
+	defer server.Close()
+
+	var html = `<html lang="en"><head><title>The fox</title><meta name="author" content="Jane Doe and Richard Roe" />` +
+		`<meta property="article:published_time" content="2024-05-01T10:00:00Z" /></head><body><article>` +
+		`<h1>The fox</h1>` + paragraph +
+		`<h2 id="1-habitat">Habitat &amp; food</h2>` + paragraph +
+		`<figure><img src="/fox.png" alt="A fox" width="100%" /><figcaption>A fox</figcaption></figure>` +
+		`<h3>Forests</h3>` + paragraph + `<img src="/missing.png" alt="Missing" />` +
+		`<h2>Behaviour</h2>` + paragraph + `<p>Some <span class="x" style="color: red" onclick="alert(1)">styled</span> text<br />and a <a href="https://example.com/?a=1&amp;b=2">link</a>.</p>` +
+		`</article></body></html>`
+	var article = parse(t, html, server.URL+"/article")
+
+	var out bytes.Buffer
+	var modified = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var err = Write(context.Background(), &out, []*readability.Result{article},
+		ImageFetcher(&readability.HTTPFetcher{Client: server.Client()}), Modified(modified))
+	assert.NoError(t, err)
+
+	archive, files := readEPUB(t, out.Bytes())
+	assert.Equal(t, "mimetype", archive[0].Name)
+	assert.Equal(t, zip.Store, archive[0].Method)
+	assert.Equal(t, "application/epub+zip", files["mimetype"])
+	assert.Contains(t, files["META-INF/container.xml"], `full-path="EPUB/package.opf"`)
+
+	var opf = files["EPUB/package.opf"]
+	assert.Contains(t, opf, "<dc:title>The fox</dc:title>")
+	assert.Contains(t, opf, "<dc:creator>Jane Doe</dc:creator>\n    <dc:creator>Richard Roe</dc:creator>")
+	assert.Contains(t, opf, "<dc:language>en</dc:language>")
+	assert.Contains(t, opf, "<dc:date>2024-05-01T10:00:00Z</dc:date>")
+	assert.Contains(t, opf, `<meta property="dcterms:modified">2024-06-01T12:00:00Z</meta>`)
+	assert.Regexp(t, `<dc:identifier id="book-id">urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}</dc:identifier>`, opf)
+	assert.Contains(t, opf, `<item id="image-1" href="images/image-1.png" media-type="image/png"/>`)
+	assert.Contains(t, opf, `<itemref idref="chapter-1"/>`)
+	assert.NotEmpty(t, files["EPUB/images/image-1.png"])
+
+	var chapter = files["EPUB/chapter-1.xhtml"]
+	assert.Contains(t, chapter, `<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" lang="en" xml:lang="en">`)
+	assert.Contains(t, chapter, "<h1>The fox</h1>\n<p>Jane Doe and Richard Roe</p>")
+	assert.Contains(t, chapter, `<img src="images/image-1.png" alt="A fox"/>`)
+	assert.NotContains(t, chapter, "missing.png")
+	assert.Contains(t, chapter, `<h2 id="heading-1">Habitat &amp; food</h2>`)
+	assert.Contains(t, chapter, `<h3 id="heading-2">Forests</h3>`)
+	assert.Contains(t, chapter, `Some <span>styled</span> text<br/>and a <a href="https://example.com/?a=1&amp;b=2">link</a>.`)
+
+	assert.Contains(t, files["EPUB/nav.xhtml"], `<li><a href="chapter-1.xhtml">The fox</a><ol>`+
+		`<li><a href="chapter-1.xhtml#heading-1">Habitat &amp; food</a><ol><li><a href="chapter-1.xhtml#heading-2">Forests</a></li></ol></li>`+
+		`<li><a href="chapter-1.xhtml#heading-3">Behaviour</a></li></ol></li>`)
+
+	t.Run("several articles", func(t *testing.T) {
+		var other = parse(t, `<html><head><title>The dog</title></head><body><article><h1>The dog</h1>`+paragraph+
+			`<img src="/fox.png" alt="Not a dog" />`+paragraph+`</article></body></html>`, server.URL+"/other")
+
+		var out bytes.Buffer
+		var err = Write(context.Background(), &out, []*readability.Result{article, other},
+			Title("Weekly digest"), Language("fr"), Identifier("urn:isbn:9780000000000"), ImageFetcher(nil))
+		assert.NoError(t, err)
+
+		_, files := readEPUB(t, out.Bytes())
+		var opf = files["EPUB/package.opf"]
+		assert.Contains(t, opf, "<dc:title>Weekly digest</dc:title>")
+		assert.Contains(t, opf, "<dc:language>fr</dc:language>")
+		assert.Contains(t, opf, `<dc:identifier id="book-id">urn:isbn:9780000000000</dc:identifier>`)
+		assert.Contains(t, opf, "<itemref idref=\"chapter-1\"/>\n    <itemref idref=\"chapter-2\"/>")
+		// Without fetcher, the images are removed.
+		assert.NotContains(t, opf, "image/png")
+		assert.NotContains(t, files["EPUB/chapter-2.xhtml"], "<img")
+		assert.Contains(t, files["EPUB/nav.xhtml"], `<li><a href="chapter-2.xhtml">The dog</a></li>`)
+	})
+
+	t.Run("no articles", func(t *testing.T) {
+		assert.ErrorIs(t, Write(context.Background(), io.Discard, nil), ErrNoArticles)
+	})
+}
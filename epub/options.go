@@ -0,0 +1,81 @@
+package epub
+
+import (
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/giulianopz/go-readability"
+)
+
+type Options struct {
+	title      string
+	lang       string
+	identifier string
+	modified   time.Time
+	fetcher    readability.Fetcher
+	logger     *slog.Logger
+}
+
+type Option func(*Options)
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func defaultOpts() *Options {
+	return &Options{
+		fetcher: &readability.HTTPFetcher{},
+		logger:  discardLogger,
+	}
+}
+
+// Title sets the title of the publication. Default: the title of the article,
+// or "Articles" for several articles.
+func Title(title string) Option {
+	return func(o *Options) {
+		o.title = title
+	}
+}
+
+// Language sets the language of the publication, as a BCP 47 tag. Default: the
+// language of the first article declaring one, or "und".
+func Language(lang string) Option {
+	return func(o *Options) {
+		o.lang = lang
+	}
+}
+
+// Identifier sets the unique identifier of the publication, e.g. an ISBN or a URN.
+// Default: a UUID derived from the titles and URLs of the articles, so that the
+// same articles always make the same publication.
+func Identifier(id string) Option {
+	return func(o *Options) {
+		o.identifier = id
+	}
+}
+
+// Modified sets the last modification time of the publication. Default: the time of writing.
+func Modified(t time.Time) Option {
+	return func(o *Options) {
+		o.modified = t
+	}
+}
+
+// ImageFetcher sets the Fetcher used to download the images of the articles, to
+// embed them in the publication. The images which cannot be downloaded are removed.
+// Default: a readability.HTTPFetcher. A nil Fetcher removes all the images.
+func ImageFetcher(f readability.Fetcher) Option {
+	return func(o *Options) {
+		o.fetcher = f
+	}
+}
+
+// Logger sets the logger used to report the images which cannot be embedded.
+// By default, nothing is logged.
+func Logger(l *slog.Logger) Option {
+	return func(o *Options) {
+		if l == nil {
+			l = discardLogger
+		}
+		o.logger = l
+	}
+}
@@ -0,0 +1,338 @@
+package epub
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/giulianopz/go-readability"
+)
+
+// Elements kept in the chapters. The other elements are replaced by their content,
+// except the dropped ones, which cannot be rendered without the source page.
+var keptElements = map[string]bool{
+	"a": true, "abbr": true, "address": true, "article": true, "aside": true, "b": true,
+	"bdi": true, "bdo": true, "blockquote": true, "br": true, "caption": true, "cite": true,
+	"code": true, "col": true, "colgroup": true, "dd": true, "del": true, "details": true,
+	"dfn": true, "div": true, "dl": true, "dt": true, "em": true, "figcaption": true,
+	"figure": true, "footer": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "header": true, "hr": true, "i": true, "img": true, "ins": true, "kbd": true,
+	"li": true, "main": true, "mark": true, "ol": true, "p": true, "pre": true, "q": true,
+	"rp": true, "rt": true, "ruby": true, "s": true, "samp": true, "section": true,
+	"small": true, "span": true, "strong": true, "sub": true, "summary": true, "sup": true,
+	"table": true, "tbody": true, "td": true, "tfoot": true, "th": true, "thead": true,
+	"time": true, "tr": true, "u": true, "ul": true, "var": true, "wbr": true,
+}
+
+var droppedElements = map[string]bool{
+	"audio": true, "base": true, "button": true, "canvas": true, "embed": true, "form": true,
+	"head": true, "iframe": true, "input": true, "link": true, "math": true, "meta": true,
+	"noscript": true, "object": true, "script": true, "select": true, "source": true,
+	"style": true, "svg": true, "template": true, "textarea": true, "title": true,
+	"track": true, "video": true,
+}
+
+// Attributes kept on all the elements.
+var globalAttributes = []string{"id", "title", "lang", "dir"}
+
+// Attributes kept on some elements only.
+var elementAttributes = map[string][]string{
+	"a":          {"href"},
+	"blockquote": {"cite"},
+	"col":        {"span"},
+	"colgroup":   {"span"},
+	"del":        {"cite", "datetime"},
+	"img":        {"src", "alt", "width", "height"},
+	"ins":        {"cite", "datetime"},
+	"li":         {"value"},
+	"ol":         {"start", "reversed"},
+	"q":          {"cite"},
+	"td":         {"colspan", "rowspan", "headers"},
+	"th":         {"colspan", "rowspan", "headers", "scope", "abbr"},
+	"time":       {"datetime"},
+}
+
+var headingLevels = map[string]int{"h1": 1, "h2": 2, "h3": 3, "h4": 4, "h5": 5, "h6": 6}
+
+var (
+	// ids must be XML names, without colon
+	xmlID = regexp.MustCompile(`^[\p{L}_][\p{L}\p{N}._-]*$`)
+	// attributes holding numbers
+	digits = regexp.MustCompile(`^[0-9]+$`)
+)
+
+var (
+	textEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attributeEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// Chapter of the publication, rendering an article.
+type chapter struct {
+	book    *book
+	article *readability.Result
+	ID      string
+	Href    string
+	Title   string
+	Byline  string
+	Lang    string
+	Dir     string
+	// XHTML content of the article
+	Content string
+	// entry of the chapter in the table of contents
+	TOC navPoint
+	// headings of the content, in document order
+	headings []readability.Heading
+	// ids of the content, to keep them unique
+	ids map[string]bool
+	out strings.Builder
+}
+
+// Entry of the table of contents.
+type navPoint struct {
+	Href     string
+	Text     string
+	Children []navPoint
+}
+
+// Renders the article content as XHTML, embedding its images.
+func (c *chapter) render(ctx context.Context) error {
+	if err := c.children(ctx, c.article.Content); err != nil {
+		return err
+	}
+	c.Content = c.out.String()
+	c.TOC = navPoint{Href: c.Href, Text: c.Title, Children: c.navPoints(c.headings)}
+	if c.Dir != "ltr" && c.Dir != "rtl" && c.Dir != "auto" {
+		c.Dir = ""
+	}
+	return nil
+}
+
+func (c *chapter) children(ctx context.Context, n *readability.Node) error {
+	for _, child := range n.ChildNodes {
+		if err := c.node(ctx, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *chapter) node(ctx context.Context, n *readability.Node) error {
+	if n.IsText() {
+		c.out.WriteString(textEscaper.Replace(xmlChars(n.GetTextContent())))
+		return nil
+	}
+	if !n.IsElement() {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var tag = strings.ToLower(n.TagName)
+	if droppedElements[tag] {
+		return nil
+	}
+	if !keptElements[tag] {
+		return c.children(ctx, n)
+	}
+
+	var attributes = c.attributes(n, tag)
+	if tag == "img" {
+		var src = attributes["src"]
+		if src == "" {
+			return nil
+		}
+		if src = c.book.image(ctx, src); src == "" {
+			return nil
+		}
+		attributes["src"] = src
+		// alt is required
+		attributes["alt"] = n.GetAttribute("alt")
+	}
+	if level, ok := headingLevels[tag]; ok {
+		if text := strings.Join(strings.Fields(n.GetTextContent()), " "); text != "" {
+			if attributes["id"] == "" {
+				attributes["id"] = c.uniqueID("heading")
+			}
+			c.headings = append(c.headings, readability.Heading{Level: level, Text: text, ID: attributes["id"]})
+		}
+	}
+
+	c.out.WriteString("<" + tag)
+	for _, name := range n.GetAttributeNames() {
+		if value, ok := attributes[name]; ok {
+			c.out.WriteString(" " + name + `="` + attributeEscaper.Replace(xmlChars(value)) + `"`)
+			delete(attributes, name)
+		}
+	}
+	// attributes added to the element
+	for _, name := range []string{"id", "alt"} {
+		if value, ok := attributes[name]; ok {
+			c.out.WriteString(" " + name + `="` + attributeEscaper.Replace(xmlChars(value)) + `"`)
+		}
+	}
+	if len(n.ChildNodes) == 0 && (tag == "br" || tag == "hr" || tag == "img" || tag == "col" || tag == "wbr") {
+		c.out.WriteString("/>")
+		return nil
+	}
+	c.out.WriteString(">")
+	if err := c.children(ctx, n); err != nil {
+		return err
+	}
+	c.out.WriteString("</" + tag + ">")
+	return nil
+}
+
+// Returns the attributes of the given element kept in the chapter.
+func (c *chapter) attributes(n *readability.Node, tag string) map[string]string {
+	var attributes = make(map[string]string)
+	for _, name := range slices.Concat(globalAttributes, elementAttributes[tag]) {
+		if !n.HasAttribute(name) {
+			continue
+		}
+		var value = strings.TrimSpace(n.GetAttribute(name))
+		switch name {
+		case "id":
+			if !xmlID.MatchString(value) || c.ids[value] {
+				continue
+			}
+			c.ids[value] = true
+		case "dir":
+			if value != "ltr" && value != "rtl" && value != "auto" {
+				continue
+			}
+		case "width", "height", "colspan", "rowspan", "span":
+			if !digits.MatchString(value) {
+				continue
+			}
+		case "start", "value":
+			if _, err := strconv.Atoi(value); err != nil {
+				continue
+			}
+		case "reversed":
+			value = "reversed"
+		}
+		attributes[name] = value
+	}
+	return attributes
+}
+
+// Returns an id made of the given prefix and the first number not used in the chapter.
+func (c *chapter) uniqueID(prefix string) string {
+	var id string
+	for i := 1; id == "" || c.ids[id]; i++ {
+		id = prefix + "-" + strconv.Itoa(i)
+	}
+	c.ids[id] = true
+	return id
+}
+
+// Returns the entries of the given headings, nested by level, e.g. H3 below H2.
+func (c *chapter) navPoints(headings []readability.Heading) []navPoint {
+	var points []navPoint
+	for len(headings) > 0 {
+		var end = 1
+		for end < len(headings) && headings[end].Level > headings[0].Level {
+			end++
+		}
+		points = append(points, navPoint{
+			Href:     c.Href + "#" + headings[0].ID,
+			Text:     headings[0].Text,
+			Children: c.navPoints(headings[1:end]),
+		})
+		headings = headings[end:]
+	}
+	return points
+}
+
+// Removes the characters which are not allowed in XML documents.
+func xmlChars(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' || r == 0xfffe || r == 0xffff {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+var templateFuncs = template.FuncMap{
+	"escape": func(s string) string {
+		return attributeEscaper.Replace(xmlChars(s))
+	},
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="EPUB/package.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+var chapterTemplate = template.Must(template.New("chapter").Funcs(templateFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops"{{if .Lang}} lang="{{escape .Lang}}" xml:lang="{{escape .Lang}}"{{end}}{{if .Dir}} dir="{{.Dir}}"{{end}}>
+<head>
+<title>{{escape .Title}}</title>
+</head>
+<body>
+<section epub:type="chapter">
+<h1>{{escape .Title}}</h1>
+{{- if .Byline}}
+<p>{{escape .Byline}}</p>
+{{- end}}
+{{.Content}}
+</section>
+</body>
+</html>
+`))
+
+var navTemplate = template.Must(template.New("nav").Funcs(templateFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" lang="{{escape .Lang}}" xml:lang="{{escape .Lang}}">
+<head>
+<title>Contents</title>
+</head>
+<body>
+<nav epub:type="toc" id="toc">
+<h1>Contents</h1>
+<ol>
+{{- range .Chapters}}
+<li>{{template "navPoint" .TOC}}</li>
+{{- end}}
+</ol>
+</nav>
+</body>
+</html>
+{{define "navPoint"}}<a href="{{escape .Href}}">{{escape .Text}}</a>{{if .Children}}<ol>{{range .Children}}<li>{{template "navPoint" .}}</li>{{end}}</ol>{{end}}{{end}}`))
+
+var packageTemplate = template.Must(template.New("package").Funcs(templateFuncs).Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id" xml:lang="{{escape .Metadata.Lang}}">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">{{escape .Metadata.Identifier}}</dc:identifier>
+    <dc:title>{{escape .Metadata.Title}}</dc:title>
+    <dc:language>{{escape .Metadata.Lang}}</dc:language>
+{{- range .Metadata.Creators}}
+    <dc:creator>{{escape .}}</dc:creator>
+{{- end}}
+{{- if .Metadata.Date}}
+    <dc:date>{{.Metadata.Date}}</dc:date>
+{{- end}}
+    <meta property="dcterms:modified">{{.Metadata.Modified}}</meta>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+{{- range .Manifest}}
+    <item id="{{.ID}}" href="{{.Href}}" media-type="{{.MediaType}}"/>
+{{- end}}
+  </manifest>
+  <spine>
+{{- range .Chapters}}
+    <itemref idref="{{.ID}}"/>
+{{- end}}
+  </spine>
+</package>
+`))
@@ -0,0 +1,61 @@
+package readability
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrEmptyDocument is returned by New when the given HTML source is empty.
+	ErrEmptyDocument = errors.New("first argument to Readability constructor should be a HTML document")
+	// ErrNoBody is returned by New when the parsed document has no <body> element.
+	ErrNoBody = errors.New("cannot parse doc: no body found")
+	// ErrNotReaderable matches the errors meaning that the document does not
+	// contain any article, e.g. *ExtractionFailedError.
+	ErrNotReaderable = errors.New("document is not readerable")
+)
+
+// TooManyElementsError is returned by Parse when the document has more
+// elements than allowed by the MaxElemsToParse option.
+type TooManyElementsError struct {
+	// number of elements found in the document
+	Found int
+	// maximum number of elements allowed
+	Limit int
+}
+
+func (e *TooManyElementsError) Error() string {
+	return fmt.Sprintf("aborting parsing document: elements_found=%d limit=%d", e.Found, e.Limit)
+}
+
+// ExtractionFailedError is returned by Parse when no content could be grabbed
+// from the document, even after relaxing the extraction flags.
+// It matches ErrNotReaderable.
+type ExtractionFailedError struct {
+	// text length of the content grabbed by each attempt, in order
+	Attempts []int
+}
+
+func (e *ExtractionFailedError) Error() string {
+	return fmt.Sprintf("cannot grab article: attempts_text_lengths=%v", e.Attempts)
+}
+
+func (e *ExtractionFailedError) Is(target error) bool {
+	return target == ErrNotReaderable
+}
+
+// StageError is returned by ParseContext when the context is done
+// before the parsing is complete. It wraps the context error.
+type StageError struct {
+	// stage interrupted
+	Stage Stage
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return string(e.Stage) + ": " + e.Err.Error()
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
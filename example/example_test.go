@@ -132,3 +132,19 @@ func ExampleIsProbablyReaderable() {
 	// Output:
 	// Contains any text? true
 }
+
+func ExampleReaderabilityReport() {
+
+	report, err := readability.ReaderabilityReport(
+		htmlSource,
+		readability.MinContentLength(140),
+		readability.MinScore(20),
+	)
+	handle(err)
+
+	fmt.Printf("Readerable? %t\n", report.Readerable)
+	fmt.Printf("Qualifying nodes: %d\n", len(report.Qualifying))
+	// Output:
+	// Readerable? true
+	// Qualifying nodes: 1
+}
@@ -0,0 +1,267 @@
+package readability
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Gemtext renders the given article content as Gemtext, the line-oriented format of the
+// Gemini protocol. Gemtext has no inline formatting: the links and images of a block are
+// listed on their own "=>" lines after it, preformatted text and data tables are fenced
+// with "```" and headings deeper than H3 are written as level 3 headings. It can be used
+// with the Serializer option, e.g. Serializer(Gemtext).
+func Gemtext(n *Node) string {
+	var w = &gemtextWriter{}
+	w.children(n)
+	return w.String()
+}
+
+// Writes the Gemtext of a node tree. The inline content of a block is collected in line,
+// and written to out with the links found in it when the block ends.
+type gemtextWriter struct {
+	out   bytes.Buffer
+	line  strings.Builder
+	links []gemtextLink
+}
+
+type gemtextLink struct {
+	url, text string
+}
+
+// Returns the Gemtext written.
+func (w *gemtextWriter) String() string {
+	w.flush()
+	return w.out.String()
+}
+
+// Writes the inline content collected, one line per paragraph, followed by its link lines.
+func (w *gemtextWriter) flush() {
+	var lines []string
+	for _, line := range strings.Split(w.line.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, gemtextTextLine(line))
+		}
+	}
+	for _, link := range w.links {
+		lines = append(lines, strings.TrimSpace("=> "+strings.ReplaceAll(link.url, " ", "%20")+" "+link.text))
+	}
+	w.line.Reset()
+	w.links = nil
+	w.block(strings.Join(lines, "\n"))
+}
+
+// Writes the given lines as a block, separated from the previous one by a blank line.
+func (w *gemtextWriter) block(s string) {
+	if s == "" {
+		return
+	}
+	if w.out.Len() > 0 {
+		w.out.WriteString("\n\n")
+	}
+	w.out.WriteString(s)
+}
+
+// Writes the given inline text, with its white space collapsed.
+func (w *gemtextWriter) text(s string) {
+	s = multipleWhitespaces.ReplaceAllString(s, " ")
+	var line = w.line.String()
+	if line == "" || strings.HasSuffix(line, "\n") || strings.HasSuffix(line, " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	w.line.WriteString(s)
+}
+
+// Adds a link line to the current block, once per URL.
+func (w *gemtextWriter) link(url, text string) {
+	if url == "" || slices.ContainsFunc(w.links, func(l gemtextLink) bool { return l.url == url }) {
+		return
+	}
+	w.links = append(w.links, gemtextLink{url: url, text: strings.Join(strings.Fields(text), " ")})
+}
+
+// Writes the given element as a block of its own.
+func (w *gemtextWriter) blockElement(n *Node) {
+	w.flush()
+	w.children(n)
+	w.flush()
+}
+
+// Renders the content of the given element apart, to prefix its lines.
+func (w *gemtextWriter) content(n *Node) string {
+	var content = &gemtextWriter{}
+	content.children(n)
+	return content.String()
+}
+
+func (w *gemtextWriter) children(n *Node) {
+	for _, child := range n.ChildNodes {
+		w.node(child)
+	}
+}
+
+func (w *gemtextWriter) node(n *Node) {
+	if n.NodeType == textNode {
+		w.text(n.GetTextContent())
+		return
+	}
+	if n.NodeType != elementNode {
+		return
+	}
+
+	switch n.TagName {
+	case "BR":
+		w.line.WriteString("\n")
+	case "H1", "H2", "H3", "H4", "H5", "H6":
+		var level = min(int(n.TagName[1]-'0'), 3)
+		var text, rest = splitGemtextLines(w.content(n))
+		w.flush()
+		if text != "" {
+			w.block(strings.TrimSpace(strings.Repeat("#", level) + " " + text + "\n" + rest))
+		} else {
+			w.block(rest)
+		}
+	case "UL", "OL":
+		w.list(n)
+	case "BLOCKQUOTE":
+		w.flush()
+		w.block(prefixGemtextLines(w.content(n), "> "))
+	case "PRE":
+		w.flush()
+		w.block(gemtextPreformatted(n.GetTextContent(), codeLanguage(n)))
+	case "TABLE":
+		if n.ReadabilityDataTable != nil && n.ReadabilityDataTable.value {
+			w.flush()
+			for _, caption := range n.getElementsByTagName("caption") {
+				w.blockElement(caption)
+			}
+			w.block(gemtextPreformatted(tableLayout(n), ""))
+		} else {
+			w.blockElement(n)
+		}
+	case "A":
+		var href = strings.TrimSpace(n.GetAttribute("href"))
+		w.children(n)
+		if href != "" && !strings.HasPrefix(href, "#") {
+			var text = n.GetTextContent()
+			if strings.TrimSpace(text) == "" {
+				for _, img := range n.getElementsByTagName("img") {
+					text = img.GetAttribute("alt")
+				}
+			}
+			w.link(href, text)
+		}
+	case "IMG":
+		w.link(strings.TrimSpace(n.GetAttribute("src")), n.GetAttribute("alt"))
+	case "VIDEO", "AUDIO":
+		var src = strings.TrimSpace(n.GetAttribute("src"))
+		for _, source := range n.getElementsByTagName("source") {
+			src = anyOf(src, strings.TrimSpace(source.GetAttribute("src")))
+		}
+		w.link(src, n.GetAttribute("title"))
+	case "IFRAME", "EMBED":
+		w.link(strings.TrimSpace(n.GetAttribute("src")), n.GetAttribute("title"))
+	case "HR":
+		w.flush()
+	case "SCRIPT", "STYLE", "NOSCRIPT", "TEMPLATE", "HEAD":
+	case "LI":
+		w.blockElement(n)
+	default:
+		if slices.Contains(markdownBlocks, n.TagName) {
+			w.blockElement(n)
+		} else {
+			w.children(n)
+		}
+	}
+}
+
+// Writes the given list, one "*" line per item. Nested lists are flattened, as Gemtext
+// has a single level of lists, and ordered lists keep their numbers.
+func (w *gemtextWriter) list(n *Node) {
+	var ordered = n.TagName == "OL"
+	var number = 1
+	if start, err := strconv.Atoi(strings.TrimSpace(n.GetAttribute("start"))); ordered && err == nil {
+		number = start
+	}
+	var items []string
+	for _, li := range n.Children {
+		if li.TagName != "LI" {
+			continue
+		}
+		var marker = "* "
+		if ordered {
+			marker += strconv.Itoa(number) + ". "
+			number++
+		}
+		var text, rest = splitGemtextLines(w.content(li))
+		if text != "" {
+			items = append(items, marker+text)
+		}
+		if rest = prefixGemtextLines(rest, "* "); rest != "" {
+			items = append(items, rest)
+		}
+	}
+	w.flush()
+	w.block(strings.Join(items, "\n"))
+}
+
+// Splits the given Gemtext into the text of its leading paragraphs, joined in a single
+// line, and its other lines, e.g. link lines.
+func splitGemtextLines(text string) (string, string) {
+	var lines = strings.Split(text, "\n")
+	var i int
+	for i < len(lines) && !isGemtextLine(lines[i]) {
+		i++
+	}
+	return strings.Join(strings.Fields(strings.Join(lines[:i], " ")), " "), strings.Trim(strings.Join(lines[i:], "\n"), "\n")
+}
+
+// Prefixes the text lines of the given Gemtext with the given prefix. The link lines,
+// the preformatted blocks and the lines already prefixed are left as is, and the
+// blank lines are removed.
+func prefixGemtextLines(text, prefix string) string {
+	var lines []string
+	var preformatted bool
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "```"):
+			preformatted = !preformatted
+		case preformatted:
+		case line == "":
+			continue
+		case !isGemtextLine(line) && !strings.HasPrefix(line, prefix):
+			line = prefix + line
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Reports whether the given line is a link line, a list item or a preformatting toggle.
+func isGemtextLine(line string) bool {
+	return strings.HasPrefix(line, "=>") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "```")
+}
+
+// Returns the given text line, prefixed with a space if it starts like another type of
+// line, e.g. "=> " for a link line, so that it is still read as text.
+func gemtextTextLine(line string) string {
+	for _, prefix := range []string{"```", "=>", "#", "* ", ">"} {
+		if strings.HasPrefix(line, prefix) {
+			return " " + line
+		}
+	}
+	return line
+}
+
+// Returns the given text as a preformatted block, with the given alt text.
+func gemtextPreformatted(text, alt string) string {
+	var lines = strings.Split(strings.Trim(strings.TrimRight(text, " \t\n"), "\n"), "\n")
+	for i, line := range lines {
+		// A line starting with ``` would end the block.
+		if strings.HasPrefix(line, "```") {
+			lines[i] = " " + line
+		}
+	}
+	return "```" + alt + "\n" + strings.Join(lines, "\n") + "\n```"
+}
@@ -0,0 +1,90 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGemtext(t *testing.T) {
+
+	var render = func(html string) string {
+		return Gemtext(newDOMParser().parse("<html><body>"+html+"</body></html>", "http://fakehost/").Body)
+	}
+
+	testCases := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			"headings and paragraphs",
+			"<h1>Title</h1><h2>The\n section</h2><h5>Deep</h5><p>Some   <b>bold</b>\n and <em>emphasized</em> text.</p><p>Second<br />line</p><hr /><p>Last</p>",
+			"# Title\n\n## The section\n\n### Deep\n\nSome bold and emphasized text.\n\nSecond\nline\n\nLast",
+		},
+		{
+			"hoisted links",
+			`<p>See <a href="http://fakehost/a b">the page</a>, <a href="#top">top</a> and <a href="http://fakehost/a b">the page again</a>.</p><p><a href="http://fakehost/"><img src="http://fakehost/fox.jpg" alt="A fox" /></a></p><h2><a href="http://fakehost/s">Section</a></h2>`,
+			"See the page, top and the page again.\n=> http://fakehost/a%20b the page\n\n" +
+				"=> http://fakehost/fox.jpg A fox\n=> http://fakehost/ A fox\n\n" +
+				"## Section\n=> http://fakehost/s Section",
+		},
+		{
+			"lists",
+			`<ul><li>One</li><li>Two<ol start="3"><li>Three</li><li><a href="http://fakehost/4">Four</a></li></ol></li></ul><ol><li><p>First</p><p>paragraph</p></li></ol>`,
+			"* One\n* Two\n* 3. Three\n* 4. Four\n=> http://fakehost/4 Four\n\n* 1. First paragraph",
+		},
+		{
+			"blockquotes",
+			"<blockquote><p>Quoted</p><p>twice, <a href=\"http://fakehost/q\">with a link</a></p></blockquote>",
+			"> Quoted\n> twice, with a link\n=> http://fakehost/q with a link",
+		},
+		{
+			"preformatted text",
+			"<p>Run:</p><pre><code class=\"language-go\">func main() {\n\tfmt.Println(\"hi\")\n}\n```\n</code></pre>",
+			"Run:\n\n```go\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n ```\n```",
+		},
+		{
+			"text lines starting like other lines",
+			"<p>```</p><p>=&gt; x</p><p># x</p><p>* x</p><p>&gt; x</p><p>Some<br />=&gt; text</p><p>Last *, # and =&gt;</p>",
+			" ```\n\n => x\n\n # x\n\n * x\n\n > x\n\nSome\n => text\n\nLast *, # and =>",
+		},
+		{
+			"text lines in blocks",
+			"<blockquote><p>=&gt; x</p></blockquote><ul><li>```</li></ul><h2>=&gt; x</h2>",
+			">  => x\n\n* ```\n\n## => x",
+		},
+		{
+			"layout tables",
+			"<table><tr><td>Cell</td><td><p>Other cell</p></td></tr></table>",
+			"Cell\n\nOther cell",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, render(tc.html))
+		})
+	}
+
+	t.Run("data tables", func(t *testing.T) {
+		var doc = newDOMParser().parse(`<html><body><table><caption>Results</caption><tr><th>Name</th><th>Score</th></tr><tr><td>Jane Doe</td><td>42</td></tr></table></body></html>`, "http://fakehost/")
+		doc.getElementsByTagName("table")[0].ReadabilityDataTable = &readabilityDataTable{value: true}
+		assert.Equal(t, "Results\n\n```\nName      Score\nJane Doe  42\n```", Gemtext(doc.Body))
+	})
+}
+
+func TestGemtextSerializer(t *testing.T) {
+
+	var paragraph = "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5) + "</p>"
+	var html = `<html><body><article><h1>Title</h1>` + paragraph + `<p>See <a href="/other">the other page</a>.</p>` + paragraph + `</article></body></html>`
+
+	reader, err := New(html, "http://fakehost/test/page.html", Serializer(Gemtext))
+	assert.NoError(t, err)
+	result, err := reader.Parse()
+	assert.NoError(t, err)
+
+	var lorem = strings.TrimSpace(strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5))
+	assert.Equal(t, "## Title\n\n"+lorem+"\n\nSee the other page.\n=> http://fakehost/other the other page\n\n"+lorem, result.HTMLContent)
+}
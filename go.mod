@@ -5,9 +5,11 @@ go 1.22.3
 require (
 	github.com/andybalholm/cascadia v1.3.2
 	github.com/google/go-cmp v0.6.0
+	github.com/rivo/uniseg v0.4.7
 	github.com/stretchr/testify v1.8.4
 	github.com/yosssi/gohtml v0.0.0-20201013000340-ee4748c638f4
 	golang.org/x/net v0.25.0
+	golang.org/x/text v0.15.0
 )
 
 require (
@@ -0,0 +1,211 @@
+package readability
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Image describes the lead image of an article.
+type Image struct {
+	// absolute URL of the image
+	URL string
+	// width in pixels, 0 if unknown
+	Width int
+	// height in pixels, 0 if unknown
+	Height int
+	// alternative text, or caption
+	Alt string
+}
+
+// Returns the image described by the given JSON-LD value,
+// which can be a URL, an ImageObject or an array of them.
+func jsonLdImage(v interface{}) *Image {
+	switch v := v.(type) {
+	case string:
+		if url := strings.TrimSpace(v); url != "" {
+			return &Image{URL: url}
+		}
+	case []interface{}:
+		for _, el := range v {
+			if img := jsonLdImage(el); img != nil {
+				return img
+			}
+		}
+	case map[string]interface{}:
+		var url = anyOf(jsonLdString(v["url"]), jsonLdString(v["contentUrl"]))
+		if url == "" {
+			return nil
+		}
+		return &Image{
+			URL:    url,
+			Width:  jsonLdInt(v["width"]),
+			Height: jsonLdInt(v["height"]),
+			Alt:    anyOf(jsonLdString(v["caption"]), jsonLdString(v["description"]), jsonLdString(v["name"])),
+		}
+	}
+	return nil
+}
+
+// Returns the image described by the Open Graph and Twitter meta tags,
+// or by a <link rel="image_src">.
+func imageFromMetaValues(values map[string]string) *Image {
+	if url := anyOf(values["og:image"], values["og:image:url"], values["og:image:secure_url"]); url != "" {
+		return &Image{
+			URL:    url,
+			Width:  parseDimension(values["og:image:width"]),
+			Height: parseDimension(values["og:image:height"]),
+			Alt:    values["og:image:alt"],
+		}
+	}
+	if url := anyOf(values["twitter:image"], values["twitter:image:src"]); url != "" {
+		return &Image{
+			URL:    url,
+			Width:  parseDimension(values["twitter:image:width"]),
+			Height: parseDimension(values["twitter:image:height"]),
+			Alt:    values["twitter:image:alt"],
+		}
+	}
+	if url := values["image_src"]; url != "" {
+		return &Image{URL: url}
+	}
+	return nil
+}
+
+// Finds the most representative image of the article content.
+// Images are ranked by their area, as declared by their size attributes
+// or estimated from their srcset descriptors; tracking pixels are skipped.
+// On a tie, the first image in document order wins.
+func (r *Readability) getLeadImageFromContent(articleContent *Node) *Image {
+	var lead *Image
+	var leadArea = -1
+	for _, img := range articleContent.getElementsByTagName("img") {
+		var width = parseDimension(img.GetAttribute("width"))
+		var height = parseDimension(img.GetAttribute("height"))
+
+		var src = strings.TrimSpace(img.GetSrc())
+		var candidate = bestSrcsetCandidate(img.GetSrcset())
+		if candidate != nil {
+			if src == "" {
+				src = candidate.url
+			}
+			if candidate.width > width {
+				// keep the aspect ratio, if known
+				if width > 0 && height > 0 {
+					height = height * candidate.width / width
+				}
+				width = candidate.width
+			}
+		}
+
+		if src == "" || isTrackingPixel(img, src) {
+			continue
+		}
+
+		var area = width * height
+		if area == 0 {
+			// assume a square image if only one dimension is known
+			area = max(width, height) * max(width, height)
+		}
+		if area > leadArea {
+			lead = &Image{
+				URL:    r.toAbsoluteURI(src),
+				Width:  width,
+				Height: height,
+				Alt:    strings.TrimSpace(img.GetAttribute("alt")),
+			}
+			leadArea = area
+		}
+	}
+	return lead
+}
+
+// Check whether the image looks like a tracking pixel or a spacer.
+func isTrackingPixel(img *Node, src string) bool {
+	if isTinyDimension(img.GetAttribute("width")) || isTinyDimension(img.GetAttribute("height")) {
+		return true
+	}
+	if b64DataUrl.MatchString(src) {
+		// Same threshold used by fixLazyImages to spot placeholders.
+		var b64starts = base64Starts.FindStringIndex(src)
+		return b64starts == nil || len(src)-(b64starts[0]+7) < 133
+	}
+	return trackingPixels.MatchString(src)
+}
+
+// Checks whether the given size attribute is declared as 1 pixel or less.
+func isTinyDimension(s string) bool {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "px")
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return err == nil && f <= 1
+}
+
+type srcsetCandidate struct {
+	url string
+	// width descriptor, 0 if missing
+	width int
+	// pixel density descriptor, 1 if missing
+	density float64
+}
+
+// Parses the candidates of a srcset attribute.
+func parseSrcset(srcset string) []*srcsetCandidate {
+	var candidates []*srcsetCandidate
+	for _, submatch := range srcsetUrl.FindAllStringSubmatch(srcset, -1) {
+		var candidate = &srcsetCandidate{
+			url:     strings.TrimSuffix(submatch[1], ","),
+			density: 1,
+		}
+		var descriptor = strings.TrimSpace(submatch[2])
+		if strings.HasSuffix(descriptor, "w") {
+			candidate.width, _ = strconv.Atoi(strings.TrimSuffix(descriptor, "w"))
+		} else if strings.HasSuffix(descriptor, "x") {
+			if density, err := strconv.ParseFloat(strings.TrimSuffix(descriptor, "x"), 64); err == nil {
+				candidate.density = density
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// Returns the largest candidate of a srcset attribute, by width or by pixel density.
+func bestSrcsetCandidate(srcset string) *srcsetCandidate {
+	var best *srcsetCandidate
+	for _, candidate := range parseSrcset(srcset) {
+		if best == nil ||
+			candidate.width > best.width ||
+			(candidate.width == best.width && candidate.density > best.density) {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// Check whether the given link relation refers to a site icon.
+func isIconRel(rel string) bool {
+	return rel == "icon" || rel == "apple-touch-icon" || rel == "apple-touch-icon-precomposed"
+}
+
+// Returns the largest icon among the given <link> elements, according to their sizes attribute.
+// Scalable icons (sizes="any") win; on a tie, the first icon in document order wins.
+func largestIcon(links []*Node) *Node {
+	var largest *Node
+	var largestSize = -1
+	for _, link := range links {
+		var size = 0
+		for _, s := range strings.Fields(strings.ToLower(link.GetAttribute("sizes"))) {
+			if s == "any" {
+				size = math.MaxInt
+				break
+			}
+			if w, h, found := strings.Cut(s, "x"); found {
+				size = max(size, parseDimension(w)*parseDimension(h))
+			}
+		}
+		if size > largestSize {
+			largest, largestSize = link, size
+		}
+	}
+	return largest
+}
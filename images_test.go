@@ -0,0 +1,127 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeadImage(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	var article = "<article>" + strings.Repeat("<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>", 5) + "%s</article>"
+
+	var parse = func(t *testing.T, head, content string) *Result {
+		var source = "<html><head>" + head + "</head><body>" + strings.Replace(article, "%s", content, 1) + "</body></html>"
+		reader, err := New(source, uri)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should extract the image from Open Graph meta tags", func(t *testing.T) {
+		var result = parse(t, `<meta property="og:image" content="/images/lead.jpg">`+
+			`<meta property="og:image:width" content="1200">`+
+			`<meta property="og:image:height" content="630">`+
+			`<meta property="og:image:alt" content="A lead image">`+
+			`<meta property="og:image" content="/images/other.jpg">`+
+			`<meta name="twitter:image" content="/images/twitter.jpg">`, "")
+		assert.Equal(t, &Image{URL: "http://fakehost/images/lead.jpg", Width: 1200, Height: 630, Alt: "A lead image"}, result.Image)
+	})
+
+	t.Run("should extract the image from Twitter meta tags", func(t *testing.T) {
+		var result = parse(t, `<meta name="twitter:image" content="https://cdn.fakehost/twitter.jpg">`+
+			`<meta name="twitter:image:alt" content="Twitter card">`, "")
+		assert.Equal(t, &Image{URL: "https://cdn.fakehost/twitter.jpg", Alt: "Twitter card"}, result.Image)
+	})
+
+	t.Run("should extract the image from a image_src link", func(t *testing.T) {
+		var result = parse(t, `<link rel="image_src" href="images/link.png">`, "")
+		assert.Equal(t, &Image{URL: "http://fakehost/test/images/link.png"}, result.Image)
+	})
+
+	t.Run("should extract the image from JSON-LD", func(t *testing.T) {
+		testCases := []struct {
+			image string
+			want  *Image
+		}{
+			{
+				image: `"/images/string.jpg"`,
+				want:  &Image{URL: "http://fakehost/images/string.jpg"},
+			},
+			{
+				image: `{"@type":"ImageObject","url":"/images/object.jpg","width":800,"height":"600","caption":"An object"}`,
+				want:  &Image{URL: "http://fakehost/images/object.jpg", Width: 800, Height: 600, Alt: "An object"},
+			},
+			{
+				image: `[{"@type":"ImageObject","url":"/images/first.jpg","width":{"@type":"QuantitativeValue","value":640}},"/images/second.jpg"]`,
+				want:  &Image{URL: "http://fakehost/images/first.jpg", Width: 640},
+			},
+		}
+		for _, tc := range testCases {
+			var result = parse(t, `<meta property="og:image" content="/images/og.jpg">`+
+				`<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","image":`+tc.image+`}</script>`, "")
+			assert.Equal(t, tc.want, result.Image)
+		}
+	})
+
+	t.Run("should fall back to the largest image in the article content", func(t *testing.T) {
+		var result = parse(t, "", `<p>`+
+			`<img src="/pixel.gif" width="1" height="1">`+
+			`<img src="/small.jpg" width="100" height="100" alt="small">`+
+			`<img src="/large.jpg" srcset="/large-480.jpg 480w, /large-1024.jpg 1024w" alt="large">`+
+			`<img src="/medium.jpg" width="300" height="200" alt="medium">`+
+			`</p>`)
+		assert.Equal(t, &Image{URL: "http://fakehost/large.jpg", Width: 1024, Alt: "large"}, result.Image)
+	})
+
+	t.Run("should skip tracking pixels in the article content", func(t *testing.T) {
+		var result = parse(t, "", `<p><img src="https://tracker.fakehost/beacon.gif"><img src="/photo.jpg" alt="photo"></p>`)
+		assert.Equal(t, &Image{URL: "http://fakehost/photo.jpg", Alt: "photo"}, result.Image)
+	})
+
+	t.Run("should not find any image", func(t *testing.T) {
+		var result = parse(t, "", "")
+		assert.Nil(t, result.Image)
+	})
+}
+
+func TestIsTrackingPixel(t *testing.T) {
+
+	testCases := []struct {
+		src           string
+		width, height string
+		want          bool
+	}{
+		{src: "/pixel.gif", want: true},
+		{src: "https://fakehost/t/beacon.gif?id=1", want: true},
+		{src: "spacer.gif", want: true},
+		{src: "https://www.google-analytics.com/collect?v=1", want: true},
+		{src: "//pixel.wp.com/g.gif?blog=1", want: true},
+		{src: "https://www.facebook.com/tr?id=1&ev=PageView", want: true},
+		{src: "https://sb.scorecardresearch.com/p?c1=2", want: true},
+		{src: "/images/photo.jpg", width: "1", height: "1", want: true},
+		{src: "/images/photo.jpg", width: "0", height: "0", want: true},
+		{src: "/images/photo.jpg", height: "1px", want: true},
+		{src: "/images/pixel-art.png", want: false},
+		{src: "/images/photo-1x1-thumb.jpg", want: false},
+		{src: "/images/tracking-shot.jpg", want: false},
+		{src: "https://fakehost/google-analytics.com/chart.png", want: false},
+		{src: "/images/photo.jpg", width: "2", height: "300", want: false},
+		{src: "/images/photo.jpg", width: "auto", height: "", want: false},
+	}
+
+	for _, tc := range testCases {
+		var img = &Node{NodeType: elementNode, TagName: "IMG"}
+		if tc.width != "" {
+			img.SetAttribute("width", tc.width)
+		}
+		if tc.height != "" {
+			img.SetAttribute("height", tc.height)
+		}
+		assert.Equal(t, tc.want, isTrackingPixel(img, tc.src), tc.src)
+	}
+}
@@ -0,0 +1,334 @@
+package readability
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Maximum nesting depth at which @graph, mainEntity and @id references are
+// followed, which also protects against reference cycles.
+const jsonLdMaxDepth = 5
+
+// Try to extract metadata from JSON-LD object.
+// For now, only Schema.org objects of type Article or its subtypes are supported.
+// The article can be the top-level object, an element of a top-level array or
+// of a @graph, or the mainEntity of another object.
+func (r *Readability) getJSONLD(doc *Node) *metadata {
+	var scripts = r.getAllNodesWithTag(doc, "script")
+
+	for _, jsonLdElement := range scripts {
+		if jsonLdElement.GetAttribute("type") != "application/ld+json" {
+			continue
+		}
+		// Strip CDATA markers if present
+		var content = cdata.ReplaceAllString(jsonLdElement.GetTextContent(), "")
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(content), &parsed); err != nil {
+			r.logger.Error("cannot unmarshal JSON-LD element content", "err", err)
+			continue
+		}
+
+		var ids = make(map[string]map[string]interface{})
+		indexJSONLD(parsed, ids)
+
+		var article = findJSONLDArticle(parsed, false, ids, 0)
+		if article == nil {
+			continue
+		}
+		// Replace references to other objects of the document, e.g. the
+		// author or publisher of an article in a @graph, with the objects.
+		article = resolveJSONLD(article, ids, 0).(map[string]interface{})
+		return r.jsonLdMetadata(article)
+	}
+	return nil
+}
+
+// Extracts the metadata from the given Schema.org article object.
+func (r *Readability) jsonLdMetadata(article map[string]interface{}) *metadata {
+	var meta = &metadata{jsonld: article}
+
+	var name = jsonLdString(article["name"])
+	var headline = jsonLdString(article["headline"])
+	if name != "" && headline != "" && name != headline {
+		// we have both name and headline element in the JSON-LD. They should both be the same but some websites like aktualne.cz
+		// put their own name into "name" and the article title to "headline" which confuses Readability. So we try to check if either
+		// "name" or "headline" closely matches the html title, and if so, use that one. If not, then we use "name" by default.
+
+		var title = r.getArticleTitle()
+		var nameMatches = r.textSimilarity(name, title) > 0.75
+		var headlineMatchs = r.textSimilarity(headline, title) > 0.75
+
+		if headlineMatchs && !nameMatches {
+			meta.title = headline
+		} else {
+			meta.title = name
+		}
+	} else {
+		meta.title = anyOf(name, headline)
+	}
+
+	meta.byline = strings.Join(jsonLdNames(article["author"]), ", ")
+	meta.authors = jsonLdAuthors(article["author"])
+	meta.excerpt = jsonLdString(article["description"])
+	if names := jsonLdNames(article["publisher"]); len(names) != 0 {
+		meta.siteName = names[0]
+	}
+	meta.datePublished = jsonLdString(article["datePublished"])
+	meta.dateModified = jsonLdString(article["dateModified"])
+	meta.image = jsonLdImage(article["image"])
+	meta.url = anyOf(jsonLdURL(article["url"]), jsonLdURL(article["mainEntityOfPage"]))
+	meta.tags = jsonLdList(article["keywords"])
+	if sections := jsonLdList(article["articleSection"]); len(sections) != 0 {
+		meta.section = sections[0]
+	}
+	meta.lang = jsonLdLanguage(article["inLanguage"])
+	return meta
+}
+
+// Returns the first Schema.org article object found in the given JSON-LD value,
+// or nil if there is none. inSchema tells whether the value inherits a Schema.org @context.
+func findJSONLDArticle(v interface{}, inSchema bool, ids map[string]map[string]interface{}, depth int) map[string]interface{} {
+	if depth > jsonLdMaxDepth {
+		return nil
+	}
+	switch v := v.(type) {
+	case []interface{}:
+		for _, el := range v {
+			if article := findJSONLDArticle(el, inSchema, ids, depth); article != nil {
+				return article
+			}
+		}
+	case map[string]interface{}:
+		v = jsonLdDeref(v, ids)
+		if ctx, found := v["@context"]; found {
+			inSchema = isSchemaContext(ctx)
+		}
+		if !inSchema {
+			return nil
+		}
+		if isJSONLDArticleType(v["@type"]) {
+			return v
+		}
+		if article := findJSONLDArticle(v["@graph"], inSchema, ids, depth+1); article != nil {
+			return article
+		}
+		return findJSONLDArticle(v["mainEntity"], inSchema, ids, depth+1)
+	}
+	return nil
+}
+
+// Checks whether the given JSON-LD @context, which can be a URL, a context
+// definition or an array of those, refers to the Schema.org vocabulary.
+func isSchemaContext(ctx interface{}) bool {
+	switch ctx := ctx.(type) {
+	case string:
+		return schemaUrl.MatchString(strings.TrimSpace(ctx))
+	case map[string]interface{}:
+		return isSchemaContext(ctx["@vocab"])
+	case []interface{}:
+		for _, el := range ctx {
+			if isSchemaContext(el) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Checks whether the given JSON-LD @type, which can be a single type or an array
+// of types, is Article or one of its subtypes.
+func isJSONLDArticleType(t interface{}) bool {
+	switch t := t.(type) {
+	case string:
+		// Types can also be given as compact ("schema:NewsArticle") or absolute IRIs.
+		if i := strings.LastIndexAny(t, "/:"); i >= 0 {
+			t = t[i+1:]
+		}
+		return jsonLdArticleTypes.MatchString(t)
+	case []interface{}:
+		for _, el := range t {
+			if isJSONLDArticleType(el) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Collects all the objects of the given JSON-LD value which are identified by an @id.
+// When several objects share an @id, the most detailed one is kept.
+func indexJSONLD(v interface{}, ids map[string]map[string]interface{}) {
+	switch v := v.(type) {
+	case []interface{}:
+		for _, el := range v {
+			indexJSONLD(el, ids)
+		}
+	case map[string]interface{}:
+		if id := jsonLdString(v["@id"]); id != "" && len(v) > len(ids[id]) {
+			ids[id] = v
+		}
+		for _, el := range v {
+			indexJSONLD(el, ids)
+		}
+	}
+}
+
+// Returns the object referenced by the given JSON-LD object if it is a reference
+// (i.e. an @id, optionally with a @type) to a more detailed object of the document.
+// Otherwise returns the given object.
+func jsonLdDeref(v map[string]interface{}, ids map[string]map[string]interface{}) map[string]interface{} {
+	if obj, found := ids[jsonLdString(v["@id"])]; found && len(obj) > len(v) {
+		return obj
+	}
+	return v
+}
+
+// Returns a copy of the given JSON-LD value where references to
+// other objects of the document are replaced with the objects.
+func resolveJSONLD(v interface{}, ids map[string]map[string]interface{}, depth int) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		var resolved = make([]interface{}, len(v))
+		for i, el := range v {
+			resolved[i] = resolveJSONLD(el, ids, depth)
+		}
+		return resolved
+	case map[string]interface{}:
+		if depth > jsonLdMaxDepth {
+			return v
+		}
+		v = jsonLdDeref(v, ids)
+		var resolved = make(map[string]interface{}, len(v))
+		for key, el := range v {
+			resolved[key] = resolveJSONLD(el, ids, depth+1)
+		}
+		return resolved
+	}
+	return v
+}
+
+// Returns the given JSON-LD person or organization, or list of those, as authors.
+func jsonLdAuthors(v interface{}) []Author {
+	switch v := v.(type) {
+	case string:
+		if name := strings.TrimSpace(v); name != "" {
+			return []Author{{Name: name}}
+		}
+	case map[string]interface{}:
+		var author = Author{
+			Name:  jsonLdString(v["name"]),
+			URL:   jsonLdURL(v["url"]),
+			Email: strings.TrimPrefix(jsonLdString(v["email"]), "mailto:"),
+		}
+		if author != (Author{}) {
+			return []Author{author}
+		}
+	case []interface{}:
+		var authors []Author
+		for _, el := range v {
+			authors = append(authors, jsonLdAuthors(el)...)
+		}
+		return authors
+	}
+	return nil
+}
+
+// Returns the names of the given JSON-LD person or organization, or list of those.
+func jsonLdNames(v interface{}) []string {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if name := jsonLdString(v["name"]); name != "" {
+			return []string{name}
+		}
+	case []interface{}:
+		var names []string
+		for _, el := range v {
+			names = append(names, jsonLdNames(el)...)
+		}
+		return names
+	}
+	return nil
+}
+
+// Returns the language code of the given JSON-LD inLanguage value, which can be
+// a code, a Language object or a list of those.
+func jsonLdLanguage(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]interface{}:
+		return anyOf(jsonLdString(v["alternateName"]), jsonLdString(v["name"]))
+	case []interface{}:
+		for _, el := range v {
+			if lang := jsonLdLanguage(el); lang != "" {
+				return lang
+			}
+		}
+	}
+	return ""
+}
+
+// Returns the given JSON-LD value as a trimmed string,
+// or an empty string if it is not a string.
+func jsonLdString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return strings.TrimSpace(s)
+	}
+	return ""
+}
+
+// Returns the given JSON-LD value as an integer. Numbers, numeric strings
+// (optionally followed by a "px" unit) and QuantitativeValue objects are supported.
+// Returns 0 if the value is not a number.
+func jsonLdInt(v interface{}) int {
+	switch v := v.(type) {
+	case float64:
+		return int(math.Round(v))
+	case string:
+		return parseDimension(v)
+	case map[string]interface{}:
+		return jsonLdInt(v["value"])
+	}
+	return 0
+}
+
+// Parses a dimension as found in width/height attributes and metadata, e.g. "640" or "640px".
+// Returns 0 if the dimension is not a positive number.
+func parseDimension(s string) int {
+	s = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), "px")
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || f <= 0 || math.IsInf(f, 0) {
+		return 0
+	}
+	return int(math.Round(f))
+}
+
+// Returns the URL referenced by the given JSON-LD value,
+// which can be a URL or an object identified by its @id or url.
+func jsonLdURL(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case map[string]interface{}:
+		return anyOf(jsonLdString(v["@id"]), jsonLdString(v["url"]))
+	}
+	return ""
+}
+
+// Returns the given JSON-LD value as a list of strings.
+// Strings are split on commas, and arrays are flattened.
+func jsonLdList(v interface{}) []string {
+	switch v := v.(type) {
+	case string:
+		return splitList(v)
+	case []interface{}:
+		var list []string
+		for _, el := range v {
+			list = append(list, jsonLdList(el)...)
+		}
+		return list
+	}
+	return nil
+}
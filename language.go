@@ -0,0 +1,202 @@
+package readability
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+)
+
+// Minimum number of letters needed to guess the language of a text.
+const minLanguageLetters = 20
+
+// Number of trigrams kept in the profile of a language or a text.
+const trigramProfileSize = 300
+
+// Minimum number of distinct trigrams needed to guess the language of a text written in the Latin alphabet.
+const minTrigrams = 20
+
+// Maximum distance between the profiles of a text and of its language: beyond it,
+// the text is deemed to be written in a language without profile.
+const maxTrigramDistance = 0.85
+
+// Minimum lead of the closest language profile over the runner-up, relative to the distance of the
+// latter, needed to guess the language of a text: a text in a language without profile, e.g. Lorem
+// ipsum, is usually as close to several profiles.
+const minTrigramLead = 0.03
+
+// Lead of the closest language profile over the runner-up from which the guess is deemed certain.
+const certainTrigramLead = 0.25
+
+// Languages which can be told from their writing system alone. The Latin script is
+// shared by too many languages: they are told apart by their trigrams.
+var scriptLanguages = []struct {
+	script *unicode.RangeTable
+	lang   string
+}{
+	{unicode.Latin, ""},
+	{unicode.Han, "zh"},
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Hangul, "ko"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Greek, "el"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Bengali, "bn"},
+	{unicode.Tamil, "ta"},
+	{unicode.Georgian, "ka"},
+	{unicode.Armenian, "hy"},
+}
+
+// Letters used by a language but not by the other languages sharing its script.
+var languageLetters = map[string]map[string]string{
+	"ru": {"uk": "іїєґ", "sr": "ђјљњћџ"},
+	"ar": {"fa": "پچژگ"},
+}
+
+// The trigram profiles of the languages written in the Latin alphabet, built from their samples.
+// Each trigram is mapped to its rank in the profile.
+var trigramProfiles = func() map[string]map[string]int {
+	var profiles = make(map[string]map[string]int, len(languageSamples))
+	for lang, sample := range languageSamples {
+		var ranks = make(map[string]int, trigramProfileSize)
+		for i, trigram := range trigramProfile(sample) {
+			ranks[trigram] = i
+		}
+		profiles[lang] = ranks
+	}
+	return profiles
+}()
+
+// DetectLanguage guesses the language of the given text from the writing system it uses
+// and, for the Latin alphabet, from the frequency of its trigrams. It returns a BCP 47 language tag
+// and a confidence between 0 and 1, or an empty tag if the language cannot be guessed.
+func DetectLanguage(text string) (string, float64) {
+	var counts = make(map[string]int)
+	var letters int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		for _, sl := range scriptLanguages {
+			if unicode.Is(sl.script, r) {
+				counts[sl.lang]++
+				break
+			}
+		}
+	}
+	if letters < minLanguageLetters {
+		return "", 0
+	}
+
+	// Japanese mixes kanji with kana, while Chinese has no kana.
+	if counts["ja"] > 0 && counts["ja"]*20 >= counts["zh"] {
+		counts["ja"] += counts["zh"]
+		counts["zh"] = 0
+	}
+
+	var lang, count = "", 0
+	for l, c := range counts {
+		if c > count || c == count && l < lang {
+			lang, count = l, c
+		}
+	}
+	var confidence = float64(count) / float64(letters)
+
+	if lang == "" {
+		var wordsLang, wordsConfidence = detectLatinLanguage(text)
+		return wordsLang, confidence * wordsConfidence
+	}
+	for other, specific := range languageLetters[lang] {
+		if strings.ContainsAny(text, specific) {
+			return other, confidence
+		}
+	}
+	return lang, confidence
+}
+
+// Guesses the language of the given text written in the Latin alphabet by comparing the ranks of
+// its most frequent trigrams with those of the language profiles, as in the "out-of-place" measure
+// of Cavnar and Trenkle, N-Gram-Based Text Categorization (1994).
+func detectLatinLanguage(text string) (string, float64) {
+	var profile = trigramProfile(text)
+	if len(profile) < minTrigrams {
+		return "", 0
+	}
+
+	var best, bestDistance, secondDistance = "", 1.0, 1.0
+	for lang, ranks := range trigramProfiles {
+		var distance = trigramDistance(profile, ranks)
+		if distance < bestDistance || distance == bestDistance && lang < best {
+			best, bestDistance, secondDistance = lang, distance, bestDistance
+		} else if distance < secondDistance {
+			secondDistance = distance
+		}
+	}
+	var lead = (secondDistance - bestDistance) / secondDistance
+	if best == "" || bestDistance > maxTrigramDistance || lead < minTrigramLead {
+		return "", 0
+	}
+	// The confidence grows with the lead over the runner-up.
+	return best, min(1, lead/certainTrigramLead)
+}
+
+// Returns the most frequent trigrams of the words of the given text, by decreasing frequency.
+// Words are padded with spaces, so that their first and last letters are part of distinct trigrams.
+func trigramProfile(text string) []string {
+	var counts = make(map[string]int)
+	var words = strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		var runes = []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+
+	var trigrams = make([]string, 0, len(counts))
+	for trigram := range counts {
+		trigrams = append(trigrams, trigram)
+	}
+	slices.SortFunc(trigrams, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	return trigrams[:min(len(trigrams), trigramProfileSize)]
+}
+
+// Measures how far the given text profile is from the ranks of a language profile, between 0 and 1:
+// each trigram counts the difference between its ranks, or the size of a profile if the language lacks it.
+func trigramDistance(profile []string, ranks map[string]int) float64 {
+	var distance int
+	for i, trigram := range profile {
+		if rank, found := ranks[trigram]; found {
+			distance += max(i-rank, rank-i)
+		} else {
+			distance += trigramProfileSize
+		}
+	}
+	return float64(distance) / float64(len(profile)*trigramProfileSize)
+}
+
+// Normalizes a language declared by the document, as in "en_US" or "en-us, fr",
+// to a BCP 47 tag. Returns an empty string if the language is not valid.
+func normalizeLanguage(lang string) string {
+	if i := strings.IndexAny(lang, ",;"); i >= 0 {
+		lang = lang[:i]
+	}
+	var tag, err = language.Parse(strings.ReplaceAll(strings.TrimSpace(lang), "_", "-"))
+	if err != nil || tag == language.Und {
+		return ""
+	}
+	return tag.String()
+}
@@ -0,0 +1,124 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	testCases := []struct {
+		text string
+		want string
+	}{
+		{"The quick brown fox jumps over the lazy dog, and it was the first time that the dog had seen a fox in the garden.", "en"},
+		{"Le renard brun rapide saute par-dessus le chien paresseux, et c'est la première fois que le chien voit un renard dans le jardin.", "fr"},
+		{"Der schnelle braune Fuchs springt über den faulen Hund, und es ist das erste Mal, dass der Hund einen Fuchs im Garten sieht.", "de"},
+		{"El rápido zorro marrón salta sobre el perro perezoso, y es la primera vez que el perro ve un zorro en el jardín.", "es"},
+		{"La volpe veloce salta sopra il cane pigro, ed è la prima volta che il cane vede una volpe nel giardino della casa.", "it"},
+		{"A raposa rápida salta sobre o cão preguiçoso, e é a primeira vez que o cão vê uma raposa no jardim da casa.", "pt"},
+		{"De snelle bruine vos springt over de luie hond, en het is de eerste keer dat de hond een vos in de tuin ziet.", "nl"},
+		{"Den snabba bruna räven hoppar över den lata hunden, och det är första gången som hunden ser en räv i trädgården.", "sv"},
+		{"Nopea ruskea kettu hyppää laiskan koiran yli, ja se on ensimmäinen kerta, kun koira näkee ketun puutarhassa.", "fi"},
+		{"Szybki brązowy lis przeskakuje nad leniwym psem i to pierwszy raz, kiedy pies widzi lisa w ogrodzie.", "pl"},
+		{"Rychlá hnědá liška skáče přes líného psa a je to poprvé, co pes vidí lišku na zahradě.", "cs"},
+		{"Vulpea maro rapidă sare peste câinele leneș și este prima dată când câinele vede o vulpe în grădină.", "ro"},
+		{"A gyors barna róka átugrik a lusta kutyán, és ez az első alkalom, hogy a kutya rókát lát a kertben.", "hu"},
+		{"Hızlı kahverengi tilki tembel köpeğin üzerinden atlıyor ve köpek bahçede ilk kez bir tilki görüyor.", "tr"},
+		{"Rubah cokelat yang cepat melompati anjing yang malas, dan ini pertama kalinya anjing itu melihat rubah di kebun.", "id"},
+		{"Con cáo nâu nhanh nhẹn nhảy qua con chó lười, và đây là lần đầu tiên con chó nhìn thấy một con cáo trong vườn.", "vi"},
+		{"Быстрая коричневая лиса прыгает через ленивую собаку, и это первый раз, когда собака видит лису в саду.", "ru"},
+		{"Швидка руда лисиця перестрибує через ледачого пса, і це перший раз, коли пес бачить лисицю в саду.", "uk"},
+		{"敏捷的棕色狐狸跳过了懒惰的狗，这是狗第一次在花园里看到狐狸。", "zh"},
+		{"素早い茶色の狐がのろまな犬を飛び越えた。犬が庭で狐を見たのは初めてだった。", "ja"},
+		{"빠른 갈색 여우가 게으른 개를 뛰어넘었고, 개가 정원에서 여우를 본 것은 처음이었다.", "ko"},
+		{"Η γρήγορη καφέ αλεπού πηδάει πάνω από τον τεμπέλη σκύλο στον κήπο του σπιτιού.", "el"},
+		{"الثعلب البني السريع يقفز فوق الكلب الكسول، وهذه أول مرة يرى فيها الكلب ثعلبا في الحديقة.", "ar"},
+		{"Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.", ""},
+		{"Too short", ""},
+		{"", ""},
+	}
+	for _, tc := range testCases {
+		var lang, confidence = DetectLanguage(tc.text)
+		assert.Equal(t, tc.want, lang, tc.text)
+		if lang == "" {
+			assert.Zero(t, confidence, tc.text)
+		} else {
+			assert.True(t, confidence > 0 && confidence <= 1, "confidence %f for %q", confidence, tc.text)
+		}
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	testCases := []struct {
+		lang string
+		want string
+	}{
+		{"en", "en"},
+		{"en_GB", "en-GB"},
+		{" en-us ", "en-US"},
+		{"de, en", "de"},
+		{"zh-hans-cn", "zh-Hans-CN"},
+		{"", ""},
+		{"not a language", ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, normalizeLanguage(tc.lang), tc.lang)
+	}
+}
+
+func TestLanguage(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	var article = "<article>" + strings.Repeat("<p>Le renard brun rapide saute par-dessus le chien paresseux, et c'est la première fois que le chien voit un renard dans le jardin.</p>", 5) + "</article>"
+
+	var parse = func(t *testing.T, html string, opts ...Option) *Result {
+		reader, err := New(html, uri, opts...)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should prefer the lang attribute", func(t *testing.T) {
+		var result = parse(t, `<html lang="fr-CA"><head><meta property="og:locale" content="en_US" /></head><body>`+article+`</body></html>`)
+		assert.Equal(t, "fr-CA", result.Lang)
+		assert.Equal(t, 1.0, result.LangConfidence)
+	})
+
+	t.Run("should keep the lang attribute as declared", func(t *testing.T) {
+		var result = parse(t, `<html lang="fr-ca"><head><meta property="og:locale" content="en_US" /></head><body>`+article+`</body></html>`)
+		assert.Equal(t, "fr-ca", result.Lang)
+	})
+
+	t.Run("should fall back to the Content-Language header", func(t *testing.T) {
+		var result = parse(t, `<html><head><meta http-equiv="Content-Language" content="fr-ca, en" />`+
+			`<meta property="og:locale" content="en_US" /></head><body>`+article+`</body></html>`)
+		assert.Equal(t, "fr-CA", result.Lang)
+		assert.Equal(t, 1.0, result.LangConfidence)
+	})
+
+	t.Run("should fall back to og:locale", func(t *testing.T) {
+		var result = parse(t, `<html><head><meta property="og:locale" content="fr_FR" />`+
+			`<meta property="og:locale:alternate" content="en_US" /></head><body>`+article+`</body></html>`)
+		assert.Equal(t, "fr-FR", result.Lang)
+	})
+
+	t.Run("should fall back to the JSON-LD inLanguage", func(t *testing.T) {
+		var result = parse(t, `<html><head><script type="application/ld+json">{"@context":"https://schema.org","@type":"Article",`+
+			`"headline":"Renard","inLanguage":{"@type":"Language","name":"French","alternateName":"fr"}}</script></head><body>`+article+`</body></html>`)
+		assert.Equal(t, "fr", result.Lang)
+	})
+
+	t.Run("should guess the language from the text content", func(t *testing.T) {
+		var result = parse(t, `<html><head></head><body>`+article+`</body></html>`)
+		assert.Equal(t, "fr", result.Lang)
+		assert.True(t, result.LangConfidence > 0.5 && result.LangConfidence < 1, "confidence %f", result.LangConfidence)
+
+		result = parse(t, `<html><head></head><body>`+article+`</body></html>`, DisableLanguageDetection(true))
+		assert.Equal(t, "", result.Lang)
+		assert.Zero(t, result.LangConfidence)
+	})
+}
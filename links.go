@@ -0,0 +1,80 @@
+package readability
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Link found in the content of an article.
+type Link struct {
+	// absolute URL of the link
+	Href string
+	// text of the link or, for image links, the alternative text of the image
+	Text string
+	// rel attribute of the link, e.g. "nofollow noopener"
+	Rel string
+	// whether the link leads to the same host as the document
+	IsInternal bool
+}
+
+// Replaces the given link with its content.
+func (r *Readability) unwrapLink(link *Node) {
+	// if the link only contains simple text content, it can be converted to a text node
+	if len(link.ChildNodes) == 1 && link.ChildNodes[0].NodeType == textNode {
+		var text = r.doc.createTextNode(link.GetTextContent())
+		link.ParentNode.ReplaceChild(text, link)
+	} else {
+		// if the link has multiple children, they should all be preserved
+		var container = r.doc.createElementNode("span")
+		for link.FirstChild() != nil {
+			container.AppendChild(link.FirstChild())
+		}
+		link.ParentNode.ReplaceChild(container, link)
+	}
+}
+
+// Checks whether the given link is marked as not endorsed by the page, with rel="nofollow" or rel="sponsored".
+func isNofollowLink(link *Node) bool {
+	var rels = strings.Fields(strings.ToLower(link.GetAttribute("rel")))
+	return slices.Contains(rels, "nofollow") || slices.Contains(rels, "sponsored")
+}
+
+// Returns the inventory entry of the given link, whose href is already absolute,
+// or nil for the links to a fragment of the document.
+func (r *Readability) newLink(link *Node) *Link {
+	var href = link.GetAttribute("href")
+	if hashUrl.MatchString(href) {
+		return nil
+	}
+	var text = r.getInnerText(link, true)
+	if text == "" {
+		for _, img := range link.getElementsByTagName("img") {
+			if text = strings.TrimSpace(img.GetAttribute("alt")); text != "" {
+				break
+			}
+		}
+	}
+	return &Link{
+		Href:       href,
+		Text:       anyOf(text, strings.TrimSpace(link.GetAttribute("title"))),
+		Rel:        strings.Join(strings.Fields(strings.ToLower(link.GetAttribute("rel"))), " "),
+		IsInternal: r.isInternalURL(href),
+	}
+}
+
+// Checks whether the given absolute URL leads to the host of the document, with or without "www.".
+func (r *Readability) isInternalURL(href string) bool {
+	var u, err = url.Parse(href)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	doc, err := url.Parse(r.doc.DocumentURI)
+	if err != nil {
+		return false
+	}
+	var trim = func(host string) string {
+		return strings.TrimPrefix(strings.ToLower(host), "www.")
+	}
+	return trim(u.Hostname()) == trim(doc.Hostname())
+}
@@ -0,0 +1,55 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinks(t *testing.T) {
+
+	const uri = "http://www.fakehost/test/page.html"
+
+	var paragraph = "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5) + "</p>"
+
+	var html = `<html><body><nav><a href="/home">Home</a></nav><article>` + paragraph +
+		`<p>See <a href="other.html">the other page</a>, <a href="//fakehost/about" rel="Author">the author</a>` +
+		` and <a href="https://otherhost/story" rel="nofollow noopener">a story</a>.</p>` + paragraph +
+		`<p><a href="https://shop.otherhost/" rel="sponsored"><img src="ad.png" alt="Our sponsor" /></a>` +
+		` <a href="javascript:void(0)">Share</a> <a href="#notes">Notes</a> <a href="mailto:jane@fakehost">Jane</a></p>` + paragraph +
+		`</article><footer><a href="https://twitter.com/fakehost">Follow us</a></footer></body></html>`
+
+	var parse = func(t *testing.T, opts ...Option) *Result {
+		reader, err := New(html, uri, opts...)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should list the links of the article", func(t *testing.T) {
+		var result = parse(t)
+		assert.Equal(t, []Link{
+			{Href: "http://www.fakehost/test/other.html", Text: "the other page", IsInternal: true},
+			{Href: "http://fakehost/about", Text: "the author", Rel: "author", IsInternal: true},
+			{Href: "https://otherhost/story", Text: "a story", Rel: "nofollow noopener"},
+			{Href: "https://shop.otherhost/", Text: "Our sponsor", Rel: "sponsored"},
+			{Href: "mailto:jane@fakehost", Text: "Jane"},
+		}, result.Links)
+		assert.Contains(t, result.HTMLContent, `<a href="https://otherhost/story" rel="nofollow noopener">a story</a>`)
+		assert.NotContains(t, result.HTMLContent, "javascript:")
+	})
+
+	t.Run("should strip the nofollow and sponsored links", func(t *testing.T) {
+		var result = parse(t, StripNofollowLinks(true))
+		assert.Equal(t, []Link{
+			{Href: "http://www.fakehost/test/other.html", Text: "the other page", IsInternal: true},
+			{Href: "http://fakehost/about", Text: "the author", Rel: "author", IsInternal: true},
+			{Href: "mailto:jane@fakehost", Text: "Jane"},
+		}, result.Links)
+		assert.NotContains(t, result.HTMLContent, "otherhost")
+		assert.Contains(t, result.HTMLContent, "a story")
+		assert.Contains(t, result.HTMLContent, `alt="Our sponsor"`)
+	})
+}
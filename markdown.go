@@ -0,0 +1,433 @@
+package readability
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MarkdownRenderer renders article content as Markdown, with GitHub Flavored Markdown
+// tables and strikethrough. Its Render method can be used with the Serializer option.
+type MarkdownRenderer struct {
+	// whether links are written in the reference style, e.g. "[text][1]", with their
+	// URLs listed at the end of the document, rather than inline
+	ReferenceLinks bool
+}
+
+// Markdown renders the given article content as Markdown, with inline links.
+// It can be used with the Serializer option, e.g. Serializer(Markdown).
+func Markdown(n *Node) string {
+	return MarkdownRenderer{}.Render(n)
+}
+
+// Render renders the given article content as Markdown.
+func (m MarkdownRenderer) Render(n *Node) string {
+	var w = &markdownWriter{referenceLinks: m.ReferenceLinks, references: new([]string)}
+	w.children(n)
+	var out = w.String()
+	if len(*w.references) > 0 {
+		out += "\n"
+		for i, href := range *w.references {
+			out += "\n[" + strconv.Itoa(i+1) + "]: " + href
+		}
+	}
+	return out
+}
+
+var (
+	// The pipe would otherwise split the cells of GitHub Flavored Markdown tables.
+	markdownEscapes = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`, "|", `\|`)
+	// markers starting a heading, a list item, a thematic break, a setext underline or a code fence
+	markdownLineStart = regexp.MustCompile(`^(?:[#+=-]|\d{1,9}[.)]|~~~)`)
+	codeLanguageClass = regexp.MustCompile(`(?:^|\s)(?:language|lang)-([\w+#.-]+)`)
+)
+
+// Elements rendered as blocks, separated by blank lines.
+var markdownBlocks = []string{
+	"ADDRESS", "ARTICLE", "ASIDE", "CAPTION", "CENTER", "DD", "DETAILS", "DIV", "DL", "DT", "FIELDSET",
+	"FIGCAPTION", "FIGURE", "FOOTER", "FORM", "HEADER", "MAIN", "NAV", "P", "SECTION", "SUMMARY",
+	"TABLE", "TD", "TH",
+}
+
+// Writes the Markdown of a node tree. Inline writers render the content of
+// inline elements, e.g. emphasis or links, which is then wrapped in markers.
+type markdownWriter struct {
+	out            bytes.Buffer
+	inline         bool
+	referenceLinks bool
+	// URLs of the reference links, shared by all the writers of a document
+	references *[]string
+}
+
+// Returns a writer for the content of an element, to be wrapped or indented.
+func (w *markdownWriter) sub(inline bool) *markdownWriter {
+	return &markdownWriter{inline: inline, referenceLinks: w.referenceLinks, references: w.references}
+}
+
+// Returns the Markdown written.
+func (w *markdownWriter) String() string {
+	if w.inline {
+		return w.out.String()
+	}
+	return strings.Trim(w.out.String(), " \n")
+}
+
+// Checks whether the next character written starts a line.
+func (w *markdownWriter) atLineStart() bool {
+	var out = w.out.String()
+	return out == "" && !w.inline || strings.HasSuffix(out, "\n")
+}
+
+// Starts a new block, separated from the previous one by a blank line.
+// The spaces ending the previous block are removed.
+func (w *markdownWriter) blankLine() {
+	w.out.Truncate(len(bytes.TrimRight(w.out.Bytes(), " \t")))
+	var out = w.out.String()
+	switch {
+	case out == "" || strings.HasSuffix(out, "\n\n"):
+	case strings.HasSuffix(out, "\n"):
+		w.out.WriteString("\n")
+	default:
+		w.out.WriteString("\n\n")
+	}
+}
+
+// Writes the given text, with its white space collapsed and the Markdown syntax escaped,
+// including the markers which would start a block at the start of a line.
+func (w *markdownWriter) text(s string) {
+	s = multipleWhitespaces.ReplaceAllString(s, " ")
+	var lineStart = w.atLineStart()
+	if lineStart || strings.HasSuffix(w.out.String(), " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	s = markdownEscapes.Replace(s)
+	if marker := markdownLineStart.FindString(s); lineStart && marker != "" {
+		// The last character of the marker is escaped, e.g. the dot of "1.", as a digit cannot be.
+		s = marker[:len(marker)-1] + `\` + s[len(marker)-1:]
+	}
+	w.out.WriteString(s)
+}
+
+// Writes the given block of Markdown, e.g. a list or a code block.
+func (w *markdownWriter) block(s string) {
+	if s == "" {
+		return
+	}
+	w.blankLine()
+	w.out.WriteString(s)
+	w.blankLine()
+}
+
+func (w *markdownWriter) children(n *Node) {
+	for _, child := range n.ChildNodes {
+		w.node(child)
+	}
+}
+
+func (w *markdownWriter) node(n *Node) {
+	if n.NodeType == textNode {
+		w.text(n.GetTextContent())
+		return
+	}
+	if n.NodeType != elementNode {
+		return
+	}
+
+	switch n.TagName {
+	case "H1", "H2", "H3", "H4", "H5", "H6":
+		var level, _ = strconv.Atoi(n.TagName[1:])
+		if text := w.inlineContent(n); text != "" {
+			w.block(strings.Repeat("#", level) + " " + strings.ReplaceAll(text, "\n", " "))
+		}
+	case "BR":
+		w.out.WriteString("  \n")
+	case "HR":
+		w.block("---")
+	case "STRONG", "B":
+		w.wrap(n, "**")
+	case "EM", "I", "CITE", "DFN":
+		// Unlike "_", "*" also emphasizes part of a word.
+		w.wrap(n, "*")
+	case "DEL", "S", "STRIKE":
+		w.wrap(n, "~~")
+	case "CODE", "KBD", "SAMP", "TT":
+		w.code(n.GetTextContent())
+	case "A":
+		w.link(n)
+	case "IMG":
+		w.image(n)
+	case "IFRAME":
+		// Embedded videos are linked to.
+		if src := strings.TrimSpace(n.GetAttribute("src")); src != "" {
+			w.block("[" + markdownEscapes.Replace(anyOf(strings.TrimSpace(n.GetAttribute("title")), src)) + "](" + markdownURL(src) + ")")
+		}
+	case "UL", "OL":
+		w.list(n)
+	case "BLOCKQUOTE":
+		var content = w.sub(false)
+		content.children(n)
+		w.block(prefixLines(content.String(), "> ", ">"))
+	case "PRE":
+		w.codeBlock(n)
+	case "TABLE":
+		if n.ReadabilityDataTable != nil && n.ReadabilityDataTable.value {
+			w.table(n)
+		} else {
+			w.blankLine()
+			w.children(n)
+			w.blankLine()
+		}
+	case "SCRIPT", "STYLE", "NOSCRIPT", "TEMPLATE", "HEAD":
+	default:
+		if slices.Contains(markdownBlocks, n.TagName) {
+			w.blankLine()
+			w.children(n)
+			w.blankLine()
+		} else {
+			w.children(n)
+		}
+	}
+}
+
+// Returns the Markdown of the content of the given element, rendered inline and trimmed.
+func (w *markdownWriter) inlineContent(n *Node) string {
+	var content = w.sub(true)
+	content.children(n)
+	return strings.TrimSpace(content.String())
+}
+
+// Writes the content of the given element between the given markers, e.g. "**" for bold.
+// The spaces around the content are kept outside the markers.
+func (w *markdownWriter) wrap(n *Node, marker string) {
+	var content = w.sub(true)
+	content.children(n)
+	var s = content.String()
+	var trimmed = strings.TrimSpace(s)
+	if trimmed == "" {
+		w.text(s)
+		return
+	}
+	if strings.HasPrefix(s, " ") {
+		w.text(" ")
+	}
+	w.out.WriteString(marker + trimmed + marker)
+	if strings.HasSuffix(s, " ") {
+		w.out.WriteString(" ")
+	}
+}
+
+// Writes the given text as inline code.
+func (w *markdownWriter) code(text string) {
+	text = multipleWhitespaces.ReplaceAllString(text, " ")
+	if strings.TrimSpace(text) == "" {
+		w.text(text)
+		return
+	}
+	// The fence is longer than the longest run of backticks of the code.
+	var fence = "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		text = " " + text + " "
+	}
+	w.out.WriteString(fence + text + fence)
+}
+
+func (w *markdownWriter) link(n *Node) {
+	var href = strings.TrimSpace(n.GetAttribute("href"))
+	if href == "" || strings.HasPrefix(href, "#") {
+		w.children(n)
+		return
+	}
+	var content = w.sub(true)
+	content.children(n)
+	var s = content.String()
+	var text = strings.TrimSpace(s)
+	if text == "" {
+		w.text(s)
+		return
+	}
+	if strings.HasPrefix(s, " ") {
+		w.text(" ")
+	}
+	if w.referenceLinks {
+		var index = slices.Index(*w.references, href)
+		if index < 0 {
+			*w.references = append(*w.references, href)
+			index = len(*w.references) - 1
+		}
+		w.out.WriteString("[" + text + "][" + strconv.Itoa(index+1) + "]")
+	} else {
+		w.out.WriteString("[" + text + "](" + markdownURL(href) + ")")
+	}
+	if strings.HasSuffix(s, " ") {
+		w.out.WriteString(" ")
+	}
+}
+
+func (w *markdownWriter) image(n *Node) {
+	var src = strings.TrimSpace(n.GetAttribute("src"))
+	if src == "" {
+		return
+	}
+	var alt = markdownEscapes.Replace(multipleWhitespaces.ReplaceAllString(strings.TrimSpace(n.GetAttribute("alt")), " "))
+	var title = ""
+	if t := strings.TrimSpace(n.GetAttribute("title")); t != "" {
+		title = ` "` + strings.ReplaceAll(t, `"`, `\"`) + `"`
+	}
+	w.out.WriteString("![" + alt + "](" + markdownURL(src) + title + ")")
+}
+
+func (w *markdownWriter) list(n *Node) {
+	var ordered = n.TagName == "OL"
+	var number = 1
+	if start, err := strconv.Atoi(strings.TrimSpace(n.GetAttribute("start"))); ordered && err == nil {
+		number = start
+	}
+	var items []string
+	var loose bool
+	for _, li := range n.Children {
+		if li.TagName != "LI" {
+			continue
+		}
+		var content = w.sub(false)
+		content.children(li)
+		var item = content.String()
+		if len(li.getElementsByTagName("p")) == 0 && len(li.getElementsByTagName("pre")) == 0 {
+			// Items without paragraphs are tight, even with nested lists.
+			item = strings.ReplaceAll(item, "\n\n", "\n")
+		} else {
+			loose = true
+		}
+		var marker = "- "
+		if ordered {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+		items = append(items, marker+prefixLines(item, strings.Repeat(" ", len(marker)), "")[len(marker):])
+	}
+	if loose {
+		w.block(strings.Join(items, "\n\n"))
+	} else {
+		w.block(strings.Join(items, "\n"))
+	}
+}
+
+func (w *markdownWriter) codeBlock(n *Node) {
+	var code = strings.TrimRight(n.GetTextContent(), " \n")
+	code = strings.TrimLeft(code, "\n")
+	var fence = "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	w.block(fence + codeLanguage(n) + "\n" + code + "\n" + fence)
+}
+
+// Returns the language of the given code block, from the language-* or lang-* class of the
+// <pre> element or of its <code> element, e.g. "go" for <pre><code class="language-go">,
+// or from the language recorded when the class was removed from the article content.
+func codeLanguage(pre *Node) string {
+	var nodes = append([]*Node{pre}, pre.getElementsByTagName("code")...)
+	for _, n := range nodes {
+		if lang := anyOf(languageFromClass(n.GetAttribute("class")), n.codeLanguage); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// Returns the language declared by the given class attribute, e.g. "go" for "language-go".
+func languageFromClass(className string) string {
+	if match := codeLanguageClass.FindStringSubmatch(className); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// Writes the given data table as a GitHub Flavored Markdown table. Its first row is the header.
+func (w *markdownWriter) table(n *Node) {
+	for _, caption := range n.getElementsByTagName("caption") {
+		var content = w.sub(false)
+		content.children(caption)
+		w.block(content.String())
+	}
+
+	var rows [][]string
+	var columns int
+	for _, tr := range n.getElementsByTagName("tr") {
+		var row []string
+		for _, cell := range tr.Children {
+			if cell.TagName != "TD" && cell.TagName != "TH" {
+				continue
+			}
+			var text = escapeCellPipes(w.inlineContent(cell))
+			text = strings.ReplaceAll(strings.ReplaceAll(text, "  \n", " "), "\n", " ")
+			row = append(row, text)
+			// Spanned columns are left empty.
+			if span, err := strconv.Atoi(cell.GetAttribute("colspan")); err == nil && span > 1 {
+				for i := 1; i < span && i < 100; i++ {
+					row = append(row, "")
+				}
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+			columns = max(columns, len(row))
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	var lines []string
+	for i, row := range rows {
+		for len(row) < columns {
+			row = append(row, "")
+		}
+		lines = append(lines, "| "+strings.Join(row, " | ")+" |")
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", columns))
+		}
+	}
+	w.block(strings.Join(lines, "\n"))
+}
+
+// Escapes the pipes of the given table cell which are not escaped yet, e.g. those of its code spans.
+func escapeCellPipes(cell string) string {
+	var out strings.Builder
+	var backslashes int
+	for _, r := range cell {
+		if r == '|' && backslashes%2 == 0 {
+			out.WriteRune('\\')
+		}
+		if r == '\\' {
+			backslashes++
+		} else {
+			backslashes = 0
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+// Prefixes the lines of the given text, e.g. with "> " for blockquotes.
+// The empty lines are prefixed with the given empty prefix.
+func prefixLines(text, prefix, emptyPrefix string) string {
+	var lines = strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = emptyPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Escapes the given URL for a Markdown link destination.
+func markdownURL(href string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29").Replace(href)
+}
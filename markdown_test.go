@@ -0,0 +1,121 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdown(t *testing.T) {
+
+	var render = func(html string) string {
+		return Markdown(newDOMParser().parse("<html><body>"+html+"</body></html>", "http://fakehost/").Body)
+	}
+
+	testCases := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			"headings and paragraphs",
+			"<h2>The\n title</h2><p>Some   <b>bold</b>, <em>emphasized </em>and <del>deleted</del> text.</p>\n<p>Second<br />line</p><hr />",
+			"## The title\n\nSome **bold**, *emphasized* and ~~deleted~~ text.\n\nSecond  \nline\n\n---",
+		},
+		{
+			"escapes",
+			"<p>2 * 3 = 6, snake_case and [brackets]</p>",
+			`2 \* 3 = 6, snake\_case and \[brackets\]`,
+		},
+		{
+			"emphasis inside words",
+			"<p>un<em>believ</em>able</p>",
+			"un*believ*able",
+		},
+		{
+			"escaped HTML",
+			"<p>&lt;script&gt;alert(1)&lt;/script&gt; and a &lt;b&gt; tag</p>",
+			`\<script\>alert(1)\</script\> and a \<b\> tag`,
+		},
+		{
+			"escaped markers at the start of lines",
+			"<p># not a heading</p><p>&gt; not a quote</p><p>- not</p><p>+ a</p><p>1. list</p><p>2) either</p><p>---</p><p>~~~ not a fence</p>" +
+				"<p>Text<br />===<br />#hashtag, 3.14 or -1</p><ul><li>- item</li></ul>",
+			`\# not a heading` + "\n\n" + `\> not a quote` + "\n\n" + `\- not` + "\n\n" + `\+ a` + "\n\n" + `1\. list` + "\n\n" + `2\) either` + "\n\n" + `\---` + "\n\n" + `~~\~ not a fence` + "\n\n" +
+				"Text  \n" + `\===` + "  \n" + `\#hashtag, 3.14 or -1` + "\n\n" + `- \- item`,
+		},
+		{
+			"links and images",
+			`<p>See <a href="http://fakehost/a (b)">the page</a> and <a href="#top">top</a>.</p><p><a href="http://fakehost/"><img src="http://fakehost/fox.jpg" alt="A fox" title="The fox" /></a></p>`,
+			`See [the page](http://fakehost/a%20%28b%29) and top.` + "\n\n" + `[![A fox](http://fakehost/fox.jpg "The fox")](http://fakehost/)`,
+		},
+		{
+			"nested lists",
+			"<ul><li>One</li><li>Two<ol start=\"3\"><li>Three</li><li>Four</li></ol></li></ul><ol><li><p>First</p><p>paragraph</p></li><li><p>Second</p></li></ol>",
+			"- One\n- Two\n  3. Three\n  4. Four\n\n1. First\n\n   paragraph\n\n2. Second",
+		},
+		{
+			"blockquotes",
+			"<blockquote><p>Quoted</p><blockquote><p>twice</p></blockquote></blockquote>",
+			"> Quoted\n>\n> > twice",
+		},
+		{
+			"code",
+			"<p>Use <code>go vet</code> or <code>a`b</code>.</p><pre><code class=\"language-go\">func main() {\n\tfmt.Println(\"*hi*\")\n}\n\n\n// trailing  \n</code></pre>",
+			"Use `go vet` or ``a`b``.\n\n```go\nfunc main() {\n\tfmt.Println(\"*hi*\")\n}\n\n\n// trailing\n```",
+		},
+		{
+			"escaped pipes",
+			"<p>Name | Score</p><p>--- | ---</p>",
+			`Name \| Score` + "\n\n" + `\--- \| ---`,
+		},
+		{
+			"layout tables",
+			"<table><tr><td>Cell</td><td><p>Other cell</p></td></tr></table>",
+			"Cell\n\nOther cell",
+		},
+		{
+			"page wrappers",
+			`<div id="readability-page-1" class="page"><p>Page 1</p></div><div id="readability-page-2" class="page"><p>Page 2</p></div>`,
+			"Page 1\n\nPage 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, render(tc.html))
+		})
+	}
+
+	t.Run("reference links", func(t *testing.T) {
+		var doc = newDOMParser().parse(`<html><body><p><a href="http://fakehost/a">A</a>, <a href="http://fakehost/b">B</a> and <a href="http://fakehost/a">A again</a></p></body></html>`, "http://fakehost/")
+		assert.Equal(t, "[A][1], [B][2] and [A again][1]\n\n[1]: http://fakehost/a\n[2]: http://fakehost/b", MarkdownRenderer{ReferenceLinks: true}.Render(doc.Body))
+	})
+}
+
+func TestMarkdownSerializer(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	var paragraph = "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5) + "</p>"
+
+	var html = `<html><body><article><h1>Title</h1>` + paragraph +
+		`<pre class="language-js"><code>console.log(1)</code></pre>` +
+		`<table><caption>Results</caption><thead><tr><th>Name</th><th>Score</th></tr></thead>` +
+		`<tbody><tr><td>Jane | Doe</td><td>42</td></tr><tr><td colspan="2">None</td></tr><tr><td><code>a|b</code></td><td>0</td></tr></tbody></table>` + paragraph +
+		`</article></body></html>`
+
+	reader, err := New(html, uri, Serializer(Markdown))
+	assert.NoError(t, err)
+	result, err := reader.Parse()
+	assert.NoError(t, err)
+
+	var lorem = strings.TrimSpace(strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5))
+	assert.Equal(t, "## Title\n\n"+lorem+"\n\n"+
+		"```js\nconsole.log(1)\n```\n\n"+
+		"Results\n\n"+
+		"| Name | Score |\n| --- | --- |\n| Jane \\| Doe | 42 |\n| None |  |\n| `a\\|b` | 0 |\n\n"+
+		lorem, result.HTMLContent)
+	assert.NotContains(t, result.TextContent, "```")
+}
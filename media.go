@@ -0,0 +1,145 @@
+package readability
+
+import (
+	"net/url"
+	"strings"
+)
+
+// MediaKind is the kind of a media item.
+type MediaKind string
+
+const (
+	MediaImage MediaKind = "image"
+	MediaVideo MediaKind = "video"
+	MediaAudio MediaKind = "audio"
+)
+
+// MediaItem describes an image, a video or a sound of the article content.
+type MediaItem struct {
+	Kind MediaKind
+	// absolute URL of the media
+	Src string
+	// absolute URL of the largest candidate of the srcset attributes of the
+	// image and of the <source> elements of its <picture>, if any
+	BestSrc string
+	// alternative text of images, or title of videos and sounds
+	Alt string
+	// text of the <figcaption> of the <figure> the media belongs to
+	Caption string
+	// width in pixels, 0 if unknown
+	Width int
+	// height in pixels, 0 if unknown
+	Height int
+	// position of the media in the raw text content of the article, in characters (runes).
+	// It is an offset into Result.TextContent only when neither Html2Text nor TextSerializer is set.
+	Position int
+	// provider of the embedded videos, e.g. "youtube" or "vimeo"
+	Provider string
+}
+
+// Lists the media of the given article content, in document order. Tracking
+// pixels are skipped, and so are the frames and objects which are not videos.
+func (r *Readability) getMedia(articleContent *Node) []MediaItem {
+	var media []MediaItem
+	var position int
+	var walk func(n *Node)
+	walk = func(n *Node) {
+		for _, child := range n.ChildNodes {
+			if child.NodeType == textNode {
+				position += len([]rune(child.GetTextContent()))
+				continue
+			}
+			if item := r.newMediaItem(child); item != nil {
+				item.Position = position
+				media = append(media, *item)
+			}
+			walk(child)
+		}
+	}
+	walk(articleContent)
+	return media
+}
+
+// Returns the media item described by the given element, or nil if it is not a media.
+func (r *Readability) newMediaItem(n *Node) *MediaItem {
+	var item = &MediaItem{
+		Src:    strings.TrimSpace(n.GetAttribute("src")),
+		Width:  parseDimension(n.GetAttribute("width")),
+		Height: parseDimension(n.GetAttribute("height")),
+	}
+	switch n.TagName {
+	case "IMG":
+		item.Kind = MediaImage
+		item.Alt = strings.TrimSpace(n.GetAttribute("alt"))
+		var srcsets = []string{n.GetSrcset()}
+		if n.ParentNode != nil && n.ParentNode.TagName == "PICTURE" {
+			for _, source := range n.ParentNode.getElementsByTagName("source") {
+				srcsets = append(srcsets, source.GetSrcset())
+			}
+		}
+		if candidate := bestSrcsetCandidate(strings.Join(srcsets, ", ")); candidate != nil {
+			item.BestSrc = r.toAbsoluteURI(candidate.url)
+			item.Src = anyOf(item.Src, item.BestSrc)
+		}
+		if item.Src == "" || isTrackingPixel(n, item.Src) {
+			return nil
+		}
+	case "VIDEO", "AUDIO":
+		item.Kind = MediaVideo
+		if n.TagName == "AUDIO" {
+			item.Kind = MediaAudio
+		}
+		item.Alt = strings.TrimSpace(n.GetAttribute("title"))
+		for _, source := range n.getElementsByTagName("source") {
+			item.Src = anyOf(item.Src, strings.TrimSpace(source.GetAttribute("src")))
+		}
+		if item.Src == "" {
+			return nil
+		}
+	case "IFRAME", "EMBED", "OBJECT":
+		item.Kind = MediaVideo
+		item.Alt = strings.TrimSpace(n.GetAttribute("title"))
+		if n.TagName == "OBJECT" {
+			item.Src = strings.TrimSpace(n.GetAttribute("data"))
+		}
+		if item.Src == "" || r.options.allowedVideoRegex == nil || !r.options.allowedVideoRegex.MatchString(item.Src) {
+			return nil
+		}
+		item.Provider = videoProvider(item.Src)
+	default:
+		return nil
+	}
+	item.Src = r.toAbsoluteURI(item.Src)
+	item.Caption = r.getCaption(n)
+	return item
+}
+
+// Returns the caption of the figure the given element belongs to, if any.
+func (r *Readability) getCaption(n *Node) string {
+	for parent := n.ParentNode; parent != nil; parent = parent.ParentNode {
+		if parent.TagName == "FIGURE" {
+			for _, caption := range parent.getElementsByTagName("figcaption") {
+				return r.getInnerText(caption, true)
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// Returns the name of the provider of the given embedded video, from the domain
+// of its URL, e.g. "youtube" for "https://www.youtube-nocookie.com/embed/xyz".
+func videoProvider(src string) string {
+	if strings.HasPrefix(src, "//") {
+		src = "https:" + src
+	}
+	var u, err = url.Parse(src)
+	if err != nil {
+		return ""
+	}
+	var labels = strings.Split(strings.ToLower(u.Hostname()), ".")
+	if len(labels) < 2 {
+		return ""
+	}
+	return strings.TrimSuffix(labels[len(labels)-2], "-nocookie")
+}
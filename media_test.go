@@ -0,0 +1,87 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedia(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	var paragraph = "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5) + "</p>"
+	var paragraphLength = len(strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5))
+
+	var html = `<html><body><article>` + paragraph +
+		`<figure><picture><source srcset="fox-800.webp 800w, fox-1600.webp 1600w" type="image/webp" />` +
+		`<img src="fox.jpg" srcset="fox-400.jpg 400w" alt="A fox" width="400" height="300" /></picture>` +
+		`<figcaption>The quick brown fox</figcaption></figure>` + paragraph +
+		`<p><img src="/pixel.gif" width="1" height="1" /></p>` +
+		`<video width="640" height="360" title="The fox jumps"><source src="/media/fox.mp4" type="video/mp4" /></video>` + paragraph +
+		`<audio src="fox.mp3"></audio>` +
+		`<iframe src="https://www.youtube-nocookie.com/embed/xyz" width="560" height="315"></iframe>` +
+		`<iframe src="https://ads.fakehost/frame.html" width="300" height="250"></iframe>` + paragraph +
+		`</article></body></html>`
+
+	reader, err := New(html, uri)
+	assert.NoError(t, err)
+	result, err := reader.Parse()
+	assert.NoError(t, err)
+
+	assert.Equal(t, []MediaItem{
+		{
+			Kind:     MediaImage,
+			Src:      "http://fakehost/test/fox.jpg",
+			BestSrc:  "http://fakehost/test/fox-1600.webp",
+			Alt:      "A fox",
+			Caption:  "The quick brown fox",
+			Width:    400,
+			Height:   300,
+			Position: paragraphLength,
+		},
+		{
+			Kind:     MediaVideo,
+			Src:      "http://fakehost/media/fox.mp4",
+			Alt:      "The fox jumps",
+			Width:    640,
+			Height:   360,
+			Position: 2*paragraphLength + len("The quick brown fox"),
+		},
+		{
+			Kind:     MediaAudio,
+			Src:      "http://fakehost/test/fox.mp3",
+			Position: 3*paragraphLength + len("The quick brown fox"),
+		},
+		{
+			Kind:     MediaVideo,
+			Src:      "https://www.youtube-nocookie.com/embed/xyz",
+			Width:    560,
+			Height:   315,
+			Position: 3*paragraphLength + len("The quick brown fox"),
+			Provider: "youtube",
+		},
+	}, result.Media)
+
+	for _, item := range result.Media {
+		assert.True(t, item.Position <= len([]rune(result.TextContent)))
+	}
+}
+
+func TestVideoProvider(t *testing.T) {
+	testCases := []struct {
+		src  string
+		want string
+	}{
+		{"https://www.youtube.com/embed/xyz", "youtube"},
+		{"//player.vimeo.com/video/123", "vimeo"},
+		{"https://player.twitch.tv/?channel=xyz", "twitch"},
+		{"https://upload.wikimedia.org/video.webm", "wikimedia"},
+		{"https://v.qq.com/iframe/player.html", "qq"},
+		{"localhost", ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, videoProvider(tc.src), tc.src)
+	}
+}
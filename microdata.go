@@ -0,0 +1,264 @@
+package readability
+
+import (
+	"strings"
+)
+
+// Attributes used by a structured data syntax to declare items, their types and their properties.
+type itemSyntax struct {
+	scope string
+	typ   string
+	prop  string
+	// whether types and properties can be given relatively to a vocab attribute
+	vocab bool
+}
+
+var (
+	// See https://html.spec.whatwg.org/multipage/microdata.html
+	microdataSyntax = itemSyntax{scope: "itemscope", typ: "itemtype", prop: "itemprop"}
+	// See https://www.w3.org/TR/rdfa-lite/
+	rdfaSyntax = itemSyntax{scope: "typeof", typ: "typeof", prop: "property", vocab: true}
+)
+
+// Try to extract metadata from the Schema.org Article (or one of its subtypes)
+// annotated with microdata or RDFa in the given document.
+func (r *Readability) getMicrodata(doc *Node) *metadata {
+	for _, syntax := range []itemSyntax{microdataSyntax, rdfaSyntax} {
+		for _, n := range doc.getElementsByTagName("*") {
+			if n.HasAttribute(syntax.scope) && isJSONLDArticleType(syntax.types(n)) {
+				return r.itemMetadata(syntax, n)
+			}
+		}
+	}
+	return nil
+}
+
+// Builds the metadata from the properties of the given article item.
+func (r *Readability) itemMetadata(syntax itemSyntax, item *Node) *metadata {
+	var props = syntax.properties(item)
+	var first = func(name string) string {
+		return syntax.first(props[name])
+	}
+	var firstText = func(name string) string {
+		return syntax.firstText(props[name])
+	}
+
+	var meta = &metadata{
+		title:         anyOf(firstText("headline"), firstText("name")),
+		excerpt:       firstText("description"),
+		datePublished: first("datePublished"),
+		dateModified:  first("dateModified"),
+		url:           anyOf(first("url"), first("mainEntityOfPage")),
+		section:       first("articleSection"),
+		lang:          first("inLanguage"),
+	}
+
+	var names []string
+	for _, author := range props["author"] {
+		if name := syntax.name(author); name != "" {
+			names = append(names, name)
+			meta.authors = append(meta.authors, syntax.author(author, name))
+		}
+	}
+	meta.byline = strings.Join(names, ", ")
+
+	for _, publisher := range props["publisher"] {
+		if meta.siteName = syntax.name(publisher); meta.siteName != "" {
+			break
+		}
+	}
+
+	for _, keywords := range props["keywords"] {
+		meta.tags = append(meta.tags, splitList(syntax.value(keywords))...)
+	}
+
+	for _, image := range props["image"] {
+		if meta.image = syntax.image(image); meta.image != nil {
+			break
+		}
+	}
+
+	return meta
+}
+
+// Returns the Schema.org types of the given item, without their namespace.
+func (s itemSyntax) types(n *Node) []interface{} {
+	var types []interface{}
+	for _, t := range strings.Fields(n.GetAttribute(s.typ)) {
+		if t = s.schemaTerm(n, t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// Returns the given type or property name relative to the Schema.org vocabulary,
+// or an empty string if it belongs to another vocabulary.
+func (s itemSyntax) schemaTerm(n *Node, term string) string {
+	for _, prefix := range []string{"http://schema.org/", "https://schema.org/", "schema:"} {
+		if len(term) > len(prefix) && strings.EqualFold(term[:len(prefix)], prefix) {
+			return term[len(prefix):]
+		}
+	}
+	if strings.ContainsAny(term, ":/") {
+		return ""
+	}
+	// Microdata properties are relative to the vocabulary of their item,
+	// while RDFa terms are relative to the closest vocab attribute.
+	if s.vocab && !hasSchemaVocab(n) {
+		return ""
+	}
+	return term
+}
+
+// Checks whether the given node or one of its ancestors sets Schema.org as the RDFa vocabulary.
+func hasSchemaVocab(n *Node) bool {
+	for ; n != nil; n = n.ParentNode {
+		if n.HasAttribute("vocab") {
+			return schemaUrl.MatchString(strings.TrimSpace(n.GetAttribute("vocab")))
+		}
+	}
+	return false
+}
+
+// Collects the property elements of the given item, by property name.
+// The properties of nested items are not included.
+func (s itemSyntax) properties(item *Node) map[string][]*Node {
+	var props = make(map[string][]*Node)
+	var collect func(n *Node)
+	collect = func(n *Node) {
+		for _, child := range n.Children {
+			for _, name := range strings.Fields(child.GetAttribute(s.prop)) {
+				if name = s.schemaTerm(child, name); name != "" {
+					props[name] = append(props[name], child)
+				}
+			}
+			if !child.HasAttribute(s.scope) {
+				collect(child)
+			}
+		}
+	}
+	collect(item)
+	return props
+}
+
+// Returns the value of the given property element.
+// See https://html.spec.whatwg.org/multipage/microdata.html#values
+func (s itemSyntax) value(n *Node) string {
+	if n.HasAttribute("content") {
+		return strings.TrimSpace(n.GetAttribute("content"))
+	}
+	var attr string
+	switch n.TagName {
+	case "AUDIO", "EMBED", "IFRAME", "IMG", "SOURCE", "TRACK", "VIDEO":
+		attr = "src"
+	case "A", "AREA", "LINK":
+		attr = "href"
+	case "OBJECT":
+		attr = "data"
+	case "DATA", "METER":
+		attr = "value"
+	case "TIME":
+		attr = "datetime"
+	}
+	if attr != "" && n.HasAttribute(attr) {
+		return strings.TrimSpace(n.GetAttribute(attr))
+	}
+	return strings.TrimSpace(normalize.ReplaceAllString(n.GetTextContent(), " "))
+}
+
+// Returns the first non-empty value of the given property elements.
+func (s itemSyntax) first(props []*Node) string {
+	for _, prop := range props {
+		if value := s.value(prop); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// Returns the text of the given property element: its content attribute, if any,
+// or its text content. Unlike the value of links or images, it is never a URL.
+func (s itemSyntax) text(n *Node) string {
+	if n.HasAttribute("content") {
+		return strings.TrimSpace(n.GetAttribute("content"))
+	}
+	return strings.TrimSpace(normalize.ReplaceAllString(n.GetTextContent(), " "))
+}
+
+// Returns the first non-empty text of the given property elements.
+func (s itemSyntax) firstText(props []*Node) string {
+	for _, prop := range props {
+		if text := s.text(prop); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// Returns the name of the given person or organization property,
+// which can be an item or a plain value.
+func (s itemSyntax) name(n *Node) string {
+	if n.HasAttribute(s.scope) {
+		return s.firstText(s.properties(n)["name"])
+	}
+	// Authors are often linked to their page: the name is the text of the link.
+	return s.text(n)
+}
+
+// Returns the author described by the given property, whose name is already known.
+func (s itemSyntax) author(n *Node, name string) Author {
+	var author = Author{Name: name}
+	if n.HasAttribute(s.scope) {
+		var props = s.properties(n)
+		author.URL = s.first(props["url"])
+		author.Email = strings.TrimPrefix(s.first(props["email"]), "mailto:")
+	} else if n.TagName == "A" {
+		author.URL = strings.TrimSpace(n.GetAttribute("href"))
+	}
+	return author
+}
+
+// Returns the image described by the given property,
+// which can be an ImageObject item or an image element.
+func (s itemSyntax) image(n *Node) *Image {
+	if !n.HasAttribute(s.scope) {
+		if url := s.value(n); url != "" {
+			return &Image{
+				URL:    url,
+				Width:  parseDimension(n.GetAttribute("width")),
+				Height: parseDimension(n.GetAttribute("height")),
+				Alt:    strings.TrimSpace(n.GetAttribute("alt")),
+			}
+		}
+		return nil
+	}
+	var props = s.properties(n)
+	for _, key := range []string{"url", "contentUrl"} {
+		for _, prop := range props[key] {
+			if img := s.image(prop); img != nil {
+				if width := parseDimension(s.first(props["width"])); width != 0 {
+					img.Width = width
+				}
+				if height := parseDimension(s.first(props["height"])); height != 0 {
+					img.Height = height
+				}
+				img.Alt = anyOf(s.first(props["caption"]), img.Alt)
+				return img
+			}
+		}
+	}
+	return nil
+}
+
+// Checks whether the given node is annotated as the body of an article with microdata or RDFa.
+func isArticleBody(n *Node) bool {
+	for _, syntax := range []itemSyntax{microdataSyntax, rdfaSyntax} {
+		for _, name := range strings.Fields(n.GetAttribute(syntax.prop)) {
+			if syntax.schemaTerm(n, name) == "articleBody" {
+				return true
+			}
+		}
+	}
+	return false
+}
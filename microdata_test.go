@@ -0,0 +1,97 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMicrodata(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	var paragraphs = strings.Repeat("<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>", 5)
+
+	var parse = func(t *testing.T, head, body string, opts ...Option) *Result {
+		reader, err := New("<html><head>"+head+"</head><body>"+body+"</body></html>", uri, opts...)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	var microdataArticle = `<article itemscope itemtype="https://schema.org/NewsArticle">` +
+		`<h1 itemprop="headline">Microdata headline</h1>` +
+		`<p>By <span itemprop="author" itemscope itemtype="https://schema.org/Person"><a itemprop="url" href="/jane"><span itemprop="name">Jane Doe</span></a></span>` +
+		` and <a itemprop="author" href="/richard">Richard Roe</a></p>` +
+		`<time itemprop="datePublished" datetime="2021-03-25T10:00:00Z">March 25</time>` +
+		`<meta itemprop="dateModified" content="2021-03-26T10:00:00Z" />` +
+		`<meta itemprop="keywords" content="go, html" />` +
+		`<meta itemprop="articleSection" content="Technology" />` +
+		`<meta itemprop="mainEntityOfPage" content="/canonical" />` +
+		`<div itemprop="publisher" itemscope itemtype="https://schema.org/Organization"><meta itemprop="name" content="Fake Host" /></div>` +
+		`<div itemprop="image" itemscope itemtype="https://schema.org/ImageObject"><img itemprop="url" src="/lead.jpg" alt="Lead" /><meta itemprop="width" content="1200" /></div>` +
+		`<div itemprop="articleBody">` + paragraphs + `</div>` +
+		`</article>`
+
+	t.Run("should extract metadata from microdata", func(t *testing.T) {
+		var result = parse(t, "", microdataArticle)
+		assert.Equal(t, "Microdata headline", result.Title)
+		assert.Equal(t, "Jane Doe, Richard Roe", result.Byline)
+		assert.Equal(t, "Fake Host", result.SiteName)
+		assert.Equal(t, "2021-03-25T10:00:00Z", result.PublishedTime)
+		assert.Equal(t, "2021-03-26T10:00:00Z", result.ModifiedTime)
+		assert.Equal(t, []string{"go", "html"}, result.Tags)
+		assert.Equal(t, "Technology", result.Section)
+		assert.Equal(t, "http://fakehost/canonical", result.CanonicalURL)
+		assert.Equal(t, &Image{URL: "http://fakehost/lead.jpg", Width: 1200, Alt: "Lead"}, result.Image)
+	})
+
+	t.Run("should use the text of the links as names", func(t *testing.T) {
+		var result = parse(t, "", `<article itemscope itemtype="https://schema.org/BlogPosting">`+
+			`<h1><a itemprop="name" href="/code/2013/post/">Linked title</a></h1>`+paragraphs+`</article>`)
+		assert.Equal(t, "Linked title", result.Title)
+	})
+
+	t.Run("should extract metadata from RDFa", func(t *testing.T) {
+		var result = parse(t, "", `<div vocab="http://schema.org/">`+
+			`<article typeof="BlogPosting">`+
+			`<h1 property="headline">RDFa headline</h1>`+
+			`<p property="description">An RDFa description</p>`+
+			`<span property="author" typeof="Person"><span property="name">Jane Doe</span></span>`+
+			`<span property="og:title">Not Schema.org</span>`+
+			`<div property="articleBody">`+paragraphs+`</div>`+
+			`</article></div>`)
+		assert.Equal(t, "RDFa headline", result.Title)
+		assert.Equal(t, "An RDFa description", result.Excerpt)
+		assert.Equal(t, "Jane Doe", result.Byline)
+	})
+
+	t.Run("should ignore RDFa outside of the Schema.org vocabulary", func(t *testing.T) {
+		var result = parse(t, "<title>Page title</title>", `<article vocab="http://example.com/" typeof="Article"><h1 property="headline">Other headline</h1>`+paragraphs+`</article>`)
+		assert.Equal(t, "Page title", result.Title)
+	})
+
+	t.Run("should prefer JSON-LD over microdata, and microdata over meta tags", func(t *testing.T) {
+		var result = parse(t, `<meta property="og:title" content="Open Graph title" />`+
+			`<meta property="og:description" content="Open Graph description" />`+
+			`<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","headline":"JSON-LD headline"}</script>`,
+			microdataArticle)
+		assert.Equal(t, "JSON-LD headline", result.Title)
+		assert.Equal(t, "Jane Doe, Richard Roe", result.Byline)
+		assert.Equal(t, "Open Graph description", result.Excerpt)
+	})
+
+	t.Run("should use articleBody as a content hint", func(t *testing.T) {
+		var body = `<main><div itemprop="articleBody">` +
+			strings.Repeat("<p>The annotated article body, with some commas, and more text to make it long enough.</p>", 4) +
+			`</div></main>` +
+			`<aside><div>` + paragraphs + `<p>Lorem ipsum dolor sit amet.</p></div></aside>`
+		var result = parse(t, "", body)
+		assert.Contains(t, result.TextContent, "The annotated article body")
+
+		result = parse(t, "", body, DisableMicrodata(true))
+		assert.NotContains(t, result.TextContent, "The annotated article body")
+	})
+}
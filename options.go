@@ -1,24 +1,37 @@
 package readability
 
 import (
+	"log/slog"
 	"regexp"
+	"time"
 
 	"golang.org/x/net/html"
 )
 
 type Options struct {
-	maxElemsToParse   int
-	nbTopCandidates   int
-	charThreshold     int
-	classesToPreserve []string
-	keepClasses       bool
-	serializer        func(doc *Node) string
-	html2text         func(htmlSrc string) string
-	disableJSONLD     bool
-	allowedVideoRegex *regexp.Regexp
-	minContentLength  int
-	minScore          float64
-	visibilityChecker func(*html.Node) bool
+	maxElemsToParse          int
+	nbTopCandidates          int
+	charThreshold            int
+	classesToPreserve        []string
+	keepClasses              bool
+	serializer               func(doc *Node) string
+	html2text                func(htmlSrc string) string
+	textSerializer           func(doc *Node) string
+	disableJSONLD            bool
+	disableMicrodata         bool
+	disableLanguageDetection bool
+	readingSpeeds            map[string]int
+	fetcher                  Fetcher
+	maxPages                 int
+	headingIds               bool
+	stripNofollowLinks       bool
+	policy                   *Policy
+	allowedVideoRegex        *regexp.Regexp
+	minContentLength         int
+	minScore                 float64
+	visibilityChecker        func(*html.Node) bool
+	stageTimeouts            map[Stage]time.Duration
+	logger                   *slog.Logger
 }
 
 type Option func(*Options)
@@ -28,6 +41,7 @@ func defaultOpts() *Options {
 		maxElemsToParse:   defaultMaxElemsToParse,
 		nbTopCandidates:   defaultNTopCandidates,
 		charThreshold:     defaultCharThreshold,
+		maxPages:          defaultMaxPages,
 		classesToPreserve: classesToPreserve,
 		allowedVideoRegex: videos,
 		serializer: func(n *Node) string {
@@ -36,6 +50,7 @@ func defaultOpts() *Options {
 		minScore:          20,
 		minContentLength:  140,
 		visibilityChecker: isNodeVisible,
+		logger:            discardLogger,
 	}
 }
 
@@ -81,12 +96,38 @@ func Html2Text(f func(string) string) Option {
 	}
 }
 
+// TextSerializer sets the function rendering the article content as Result.TextContent,
+// e.g. PlainText or the Render method of a TextRenderer. By default, TextContent is the
+// raw text content of the article. Html2Text takes precedence over it.
+func TextSerializer(f func(*Node) string) Option {
+	return func(o *Options) {
+		o.textSerializer = f
+	}
+}
+
 func DisableJSONLD(b bool) Option {
 	return func(o *Options) {
 		o.disableJSONLD = b
 	}
 }
 
+// DisableMicrodata disables the extraction of metadata from the Schema.org article
+// annotated with microdata or RDFa, used after JSON-LD and before the meta tags,
+// and the use of its articleBody property as a hint for locating the article content.
+func DisableMicrodata(b bool) Option {
+	return func(o *Options) {
+		o.disableMicrodata = b
+	}
+}
+
+// DisableLanguageDetection disables the guessing of the article language from its
+// text content, when the document does not declare its language.
+func DisableLanguageDetection(b bool) Option {
+	return func(o *Options) {
+		o.disableLanguageDetection = b
+	}
+}
+
 func AllowedVideoRegex(rgx *regexp.Regexp) Option {
 	return func(o *Options) {
 		o.allowedVideoRegex = rgx
@@ -110,3 +151,84 @@ func VisibilityChecker(f func(*html.Node) bool) Option {
 		o.visibilityChecker = f
 	}
 }
+
+// StageTimeout sets the maximum duration of the given stage of ParseContext.
+// The timeout of StagePrepArticle is applied to every grabArticle attempt.
+func StageTimeout(stage Stage, d time.Duration) Option {
+	return func(o *Options) {
+		if o.stageTimeouts == nil {
+			o.stageTimeouts = make(map[Stage]time.Duration)
+		}
+		o.stageTimeouts[stage] = d
+	}
+}
+
+// ReadingSpeed sets the reading speed used to estimate the reading time of the articles
+// in the given language, e.g. "de" or "pt-BR", in words per minute or, for Chinese,
+// Japanese and Thai, in characters per minute. The empty language sets the speed of the
+// languages without a default one.
+func ReadingSpeed(lang string, perMinute int) Option {
+	return func(o *Options) {
+		if o.readingSpeeds == nil {
+			o.readingSpeeds = make(map[string]int)
+		}
+		o.readingSpeeds[lang] = perMinute
+	}
+}
+
+// PageFetcher sets the Fetcher used to retrieve the next pages of the articles split
+// over several pages. The content of each page is appended to the article, in its own
+// readability-page-N container. By default, only the given document is parsed.
+func PageFetcher(f Fetcher) Option {
+	return func(o *Options) {
+		o.fetcher = f
+	}
+}
+
+// MaxPages sets the maximum number of pages of an article parsed when a PageFetcher
+// is set, including the first one. Default: 10. Zero means no limit.
+func MaxPages(n int) Option {
+	return func(o *Options) {
+		o.maxPages = n
+	}
+}
+
+// HeadingIds gives the headings of the article without id attribute an id generated
+// from their text, e.g. "what-is-readability", so that Result.Outline can link to all
+// of them. Default: false.
+func HeadingIds(b bool) Option {
+	return func(o *Options) {
+		o.headingIds = b
+	}
+}
+
+// StripNofollowLinks removes the links marked with rel="nofollow" or rel="sponsored"
+// from the article, keeping their content, and from Result.Links. Default: false.
+func StripNofollowLinks(b bool) Option {
+	return func(o *Options) {
+		o.stripNofollowLinks = b
+	}
+}
+
+// Sanitize removes from the article content, before it is serialized, the elements,
+// attributes and URLs not allowed by the given policy, e.g. DefaultPolicy(), so that it
+// can be rendered in a web page: scripts, event handlers, frames, javascript: URLs, etc.
+// The links whose URLs are not allowed are also removed from Result.Links. By default,
+// the content is not sanitized.
+func Sanitize(p *Policy) Option {
+	return func(o *Options) {
+		o.policy = p
+	}
+}
+
+// Logger sets the logger used to report debug information and recoverable errors.
+// Records are enriched with the document URI and the number of the grabArticle attempt.
+// By default, nothing is logged.
+func Logger(l *slog.Logger) Option {
+	return func(o *Options) {
+		if l == nil {
+			l = discardLogger
+		}
+		o.logger = l
+	}
+}
@@ -0,0 +1,106 @@
+package readability
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Heading of a section of an article.
+type Heading struct {
+	// level of the heading, from 1 to 6
+	Level int
+	Text  string
+	// id attribute of the heading, to link to its section
+	ID string
+	// headings of the subsections
+	Children []Heading
+}
+
+var headingTags = []string{"H1", "H2", "H3", "H4", "H5", "H6"}
+
+// Returns the headings found in the given node, in document order.
+func getHeadings(n *Node) []*Node {
+	var headings []*Node
+	for _, el := range n.getElementsByTagName("*") {
+		if slices.Contains(headingTags, el.TagName) {
+			headings = append(headings, el)
+		}
+	}
+	return headings
+}
+
+// Builds the outline of the given article content from its headings. The headings
+// nested below a heading, e.g. H3 below H2, are its children.
+func (r *Readability) getOutline(articleContent *Node) []Heading {
+	var outline []Heading
+	// path to the last heading added, as indexes in the children of each level
+	var path []int
+	var levels []int
+	for _, n := range getHeadings(articleContent) {
+		var text = r.getInnerText(n, true)
+		if text == "" {
+			continue
+		}
+		var heading = Heading{Level: int(n.TagName[1] - '0'), Text: text, ID: n.GetId()}
+		for len(levels) > 0 && levels[len(levels)-1] >= heading.Level {
+			levels, path = levels[:len(levels)-1], path[:len(path)-1]
+		}
+		var siblings = &outline
+		for _, i := range path {
+			siblings = &(*siblings)[i].Children
+		}
+		*siblings = append(*siblings, heading)
+		levels, path = append(levels, heading.Level), append(path, len(*siblings)-1)
+	}
+	return outline
+}
+
+// Gives the headings of the given article content without id attribute an id
+// generated from their text, unique in the article, so that they can be linked to.
+func (r *Readability) setHeadingIds(articleContent *Node) {
+	var ids = make(map[string]bool)
+	for _, n := range articleContent.getElementsByTagName("*") {
+		if id := n.GetId(); id != "" {
+			ids[id] = true
+		}
+	}
+	for _, n := range getHeadings(articleContent) {
+		if n.GetId() != "" {
+			continue
+		}
+		var slug = slugify(r.getInnerText(n, true))
+		if slug == "" {
+			slug = "section"
+		}
+		var id = slug
+		for i := 2; ids[id]; i++ {
+			id = slug + "-" + strconv.Itoa(i)
+		}
+		ids[id] = true
+		n.SetId(id)
+	}
+}
+
+// Returns the given text in lower case, with its words joined with hyphens,
+// e.g. "what-is-readability" for "What is Readability?". Letters of all scripts are kept.
+func slugify(text string) string {
+	var slug strings.Builder
+	var hyphen bool
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.L, unicode.M, unicode.Nd):
+			if hyphen && slug.Len() > 0 {
+				slug.WriteByte('-')
+			}
+			slug.WriteRune(r)
+			hyphen = false
+		case r == '\'' || r == '’':
+			// "don't" becomes "dont"
+		default:
+			hyphen = true
+		}
+	}
+	return slug.String()
+}
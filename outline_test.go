@@ -0,0 +1,82 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	testCases := []struct {
+		text string
+		want string
+	}{
+		{"What is Readability?", "what-is-readability"},
+		{"  Don't   panic!  ", "dont-panic"},
+		{"1. Introduction", "1-introduction"},
+		{"Übermäßig große Bäume", "übermäßig-große-bäume"},
+		{"敏捷的棕色狐狸", "敏捷的棕色狐狸"},
+		{"???", ""},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, slugify(tc.text), tc.text)
+	}
+}
+
+func TestOutline(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	var paragraph = "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5) + "</p>"
+
+	var html = `<html><head><title>The article</title></head><body><article>` +
+		`<h1>The article</h1>` + paragraph +
+		`<h2>Introduction</h2>` + paragraph +
+		`<h2 id="methods">Methods</h2>` + paragraph +
+		`<h3>Introduction</h3>` + paragraph +
+		`<h4>Details</h4>` + paragraph +
+		`<h3>Results</h3>` + paragraph +
+		`<h2>Conclusion</h2>` + paragraph +
+		`</article></body></html>`
+
+	var parse = func(t *testing.T, opts ...Option) *Result {
+		reader, err := New(html, uri, opts...)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should nest the headings by level", func(t *testing.T) {
+		var result = parse(t)
+		assert.Equal(t, []Heading{
+			{Level: 2, Text: "Introduction"},
+			{Level: 2, Text: "Methods", ID: "methods", Children: []Heading{
+				{Level: 3, Text: "Introduction", Children: []Heading{
+					{Level: 4, Text: "Details"},
+				}},
+				{Level: 3, Text: "Results"},
+			}},
+			{Level: 2, Text: "Conclusion"},
+		}, result.Outline)
+		assert.NotContains(t, result.HTMLContent, `id="introduction"`)
+	})
+
+	t.Run("should give ids to the headings", func(t *testing.T) {
+		var result = parse(t, HeadingIds(true))
+		assert.Equal(t, []Heading{
+			{Level: 2, Text: "Introduction", ID: "introduction"},
+			{Level: 2, Text: "Methods", ID: "methods", Children: []Heading{
+				{Level: 3, Text: "Introduction", ID: "introduction-2", Children: []Heading{
+					{Level: 4, Text: "Details", ID: "details"},
+				}},
+				{Level: 3, Text: "Results", ID: "results"},
+			}},
+			{Level: 2, Text: "Conclusion", ID: "conclusion"},
+		}, result.Outline)
+		assert.Contains(t, result.HTMLContent, `<h2 id="introduction">Introduction</h2>`)
+		assert.Contains(t, result.HTMLContent, `<h3 id="introduction-2">Introduction</h3>`)
+		assert.Contains(t, result.HTMLContent, `<h2 id="methods">Methods</h2>`)
+	})
+}
@@ -0,0 +1,264 @@
+package readability
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Fetcher retrieves the next pages of the articles split over several pages.
+type Fetcher interface {
+	// Fetch returns the raw document found at the given URL and its Content-Type
+	// header value, used to detect its character encoding. The caller closes the body.
+	Fetch(ctx context.Context, pageURL *url.URL) (body io.ReadCloser, contentType string, err error)
+}
+
+// HTTPFetcher is a Fetcher sending GET requests with an HTTP client.
+type HTTPFetcher struct {
+	// client used to send the requests, http.DefaultClient if nil
+	Client *http.Client
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, pageURL *url.URL) (io.ReadCloser, string, error) {
+	var client = f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, "", fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
+
+// Fetches the next pages of the article and appends their content to the given
+// article content, one readability-page-N container per page. The pages are followed
+// until no next page is found, a page is already parsed or the MaxPages limit is reached.
+func (r *Readability) appendNextPages(ctx context.Context, articleContent *Node, nextPage string, pages map[string]bool) error {
+	var texts = []string{r.getInnerText(articleContent, true)}
+	for n := 2; nextPage != "" && (r.options.maxPages <= 0 || n <= r.options.maxPages); n++ {
+		pages[nextPage] = true
+		var content, next, err = r.grabNextPage(ctx, nextPage, pages)
+		if err := checkContext(ctx, StageFetchPages); err != nil {
+			return err
+		}
+		if err != nil {
+			// The article is still readable without the next pages.
+			r.logger.Error("cannot grab next page", slog.String("page", nextPage), slog.String("err", err.Error()))
+			return nil
+		}
+		if content == nil {
+			r.logger.Debug("No content found in next page", "page", nextPage)
+			return nil
+		}
+		// Some sites ignore the page number they do not know and serve a page already parsed.
+		var text = r.getInnerText(content, true)
+		for _, t := range texts {
+			if t == text {
+				r.logger.Debug("Next page already parsed", "page", nextPage)
+				return nil
+			}
+		}
+		texts = append(texts, text)
+
+		content.SetId("readability-page-" + strconv.Itoa(n))
+		content.SetClassName("page")
+		articleContent.AppendChild(content)
+		nextPage = next
+	}
+	return nil
+}
+
+// Fetches the page at the given URL and grabs its content. Returns the content,
+// nil if none was found, and the URL of the page following it, if any.
+func (r *Readability) grabNextPage(ctx context.Context, pageURL string, pages map[string]bool) (*Node, string, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, "", err
+	}
+	body, contentType, err := r.options.fetcher.Fetch(ctx, u)
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot fetch page: %w", err)
+	}
+	defer body.Close()
+
+	page, err := NewFromReader(body, u, contentType, Logger(r.options.logger))
+	if err != nil {
+		return nil, "", err
+	}
+	page.options = r.options
+	if r.options.maxElemsToParse > 0 {
+		var numTags = len(page.doc.getElementsByTagName("*"))
+		if numTags > r.options.maxElemsToParse {
+			return nil, "", &TooManyElementsError{Found: numTags, Limit: r.options.maxElemsToParse}
+		}
+	}
+
+	page.unwrapNoscriptImages(page.doc)
+	page.removeScripts(page.doc)
+	if err := page.prepDocument(ctx); err != nil {
+		return nil, "", err
+	}
+	// The headers repeating the title of the article are removed from all its pages.
+	page.articleTitle = r.articleTitle
+
+	var next = page.findNextPageLink(pages)
+	content, err := page.grabArticle(ctx, page.doc.Body)
+	if err != nil || content == nil {
+		return nil, "", err
+	}
+	// The URLs are relative to the page, not to the first one.
+	page.fixRelativeUris(content)
+	if div := content.FirstElementChild(); div != nil {
+		content = div
+	}
+	return content, next, nil
+}
+
+// Checks whether the given URL is on the host of the given page: the next pages
+// are on the same site, and other hosts must not be fetched on behalf of the page.
+func isSameHost(href string, pageURL *url.URL) bool {
+	var linkURL, err = url.Parse(href)
+	return err == nil && linkURL.Host == pageURL.Host
+}
+
+// Finds the link to the next page of the article, among those not already parsed.
+// The link can be declared with <link rel="next">; otherwise the links of the page
+// are scored by their text, their URL and their ancestors, as the Arc90 Readability did.
+func (r *Readability) findNextPageLink(pages map[string]bool) string {
+	var pageURL, err = url.Parse(r.doc.DocumentURI)
+	if err != nil {
+		return ""
+	}
+	var baseURL = firstPageURL(pageURL)
+	pages[normalizePageURL(r.doc.DocumentURI)] = true
+
+	for _, link := range r.getAllNodesWithTag(r.doc, "link") {
+		if !strings.Contains(" "+strings.ToLower(link.GetAttribute("rel"))+" ", " next ") {
+			continue
+		}
+		var href = normalizePageURL(r.toAbsoluteURI(strings.TrimSpace(link.GetAttribute("href"))))
+		if href == "" || pages[href] || !isSameHost(href, pageURL) {
+			continue
+		}
+		return href
+	}
+
+	var scores = make(map[string]int)
+	var bestLink string
+	for _, link := range r.getAllNodesWithTag(r.doc.Body, "a") {
+		var href = normalizePageURL(r.toAbsoluteURI(strings.TrimSpace(link.GetAttribute("href"))))
+		if href == "" || href == baseURL || pages[href] {
+			continue
+		}
+		if !isSameHost(href, pageURL) {
+			continue
+		}
+		var linkText = r.getInnerText(link, true)
+		if extraneous.MatchString(linkText) || len([]rune(linkText)) > 25 {
+			continue
+		}
+		// The URL of the next page only differs by its page number.
+		if !strings.ContainsAny(strings.TrimPrefix(href, baseURL), "0123456789") {
+			continue
+		}
+
+		var score int
+		if !strings.HasPrefix(href, baseURL) {
+			score -= 25
+		}
+		var linkData = linkText + " " + link.GetClassName() + " " + link.GetId()
+		if nextLink.MatchString(linkData) {
+			score += 50
+		}
+		if pagination.MatchString(linkData) {
+			score += 25
+		}
+		if firstOrLastLink.MatchString(linkData) && !nextLink.MatchString(linkText) {
+			score -= 65
+		}
+		if negative.MatchString(linkData) || extraneous.MatchString(linkData) {
+			score -= 50
+		}
+		if prevLink.MatchString(linkData) {
+			score -= 200
+		}
+
+		// Links of pagination blocks are more likely to lead to the next page.
+		var positiveParent, negativeParent bool
+		for parent := link.ParentNode; parent != nil && parent.NodeType == elementNode; parent = parent.ParentNode {
+			var parentData = parent.GetClassName() + " " + parent.GetId()
+			if !positiveParent && pagination.MatchString(parentData) {
+				positiveParent = true
+				score += 25
+			}
+			if !negativeParent && negative.MatchString(parentData) && !positive.MatchString(parentData) {
+				negativeParent = true
+				score -= 25
+			}
+		}
+
+		if pageNumberURL.MatchString(href) {
+			score += 25
+		}
+		if extraneous.MatchString(href) {
+			score -= 15
+		}
+		if number, err := strconv.Atoi(linkText); err == nil && number > 0 {
+			if number == 1 {
+				score -= 10
+			} else {
+				score += max(0, 10-number)
+			}
+		}
+
+		// A page is often linked several times, e.g. by its number and by "Next".
+		scores[href] += score
+		if bestLink == "" || scores[href] > scores[bestLink] {
+			bestLink = href
+		}
+	}
+
+	if bestLink != "" && scores[bestLink] >= 50 {
+		r.logger.Debug("Found next page", "href", bestLink, "score", scores[bestLink])
+		return bestLink
+	}
+	return ""
+}
+
+// Returns the URL of the first page of an article from the URL of one of its pages,
+// without query, fragment and trailing page number.
+func firstPageURL(pageURL *url.URL) string {
+	var u = *pageURL
+	u.RawQuery, u.Fragment = "", ""
+	var segments = strings.Split(strings.TrimSuffix(u.Path, "/"), "/")
+	if len(segments) > 1 && pageNumberSegment.MatchString(segments[len(segments)-1]) {
+		segments = segments[:len(segments)-1]
+	}
+	u.Path = strings.Join(segments, "/")
+	u.RawPath = ""
+	return normalizePageURL(u.String())
+}
+
+// Normalizes the given page URL, without fragment nor trailing slash, so that the
+// same page is always found under the same URL.
+func normalizePageURL(uri string) string {
+	uri, _, _ = strings.Cut(uri, "#")
+	if strings.HasPrefix(strings.ToLower(uri), "javascript:") || strings.HasPrefix(strings.ToLower(uri), "mailto:") {
+		return ""
+	}
+	return strings.TrimSuffix(uri, "/")
+}
@@ -0,0 +1,176 @@
+package readability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Serves an article split over the given number of pages, at /story/1, /story/2, etc.
+// The last page links back to the first one.
+func newPagedArticleServer(pages int) (*httptest.Server, *[]string) {
+	var mu sync.Mutex
+	var requested []string
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		requested = append(requested, req.URL.Path)
+		mu.Unlock()
+		var n int
+		if _, err := fmt.Sscanf(req.URL.Path, "/story/%d", &n); err != nil || n < 1 || n > pages {
+			http.NotFound(w, req)
+			return
+		}
+		var next = n%pages + 1
+		var pagination = `<div class="pagination">`
+		if n > 1 {
+			pagination += fmt.Sprintf(`<a href="/story/%d">« Previous</a> `, n-1)
+		}
+		for i := 1; i <= pages; i++ {
+			pagination += fmt.Sprintf(`<a href="/story/%d">%d</a> `, i, i)
+		}
+		pagination += fmt.Sprintf(`<a href="/story/%d">Next »</a></div>`, next)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprintf(w, `<html><head><title>The story</title></head><body>`+
+			`<nav><a href="/">Home</a> <a href="/about">About</a></nav>`+
+			`<article><h1>The story</h1>%s<img src="image-%d.jpg" />%s</article>`+
+			`<footer><a href="/comments/%d">12 comments</a></footer></body></html>`,
+			strings.Repeat(fmt.Sprintf("<p>This is the paragraph of the page %d of the story, which is long enough to be kept by Readability, as it has more than a few words, and even commas.</p>", n), 4),
+			n, pagination, n)
+	}))
+	return server, &requested
+}
+
+type failingFetcher struct{}
+
+func (failingFetcher) Fetch(ctx context.Context, pageURL *url.URL) (io.ReadCloser, string, error) {
+	return nil, "", errors.New("connection refused")
+}
+
+func mustParseURL(t *testing.T, uri string) *url.URL {
+	u, err := url.Parse(uri)
+	assert.NoError(t, err)
+	return u
+}
+
+func TestFindNextPageLink(t *testing.T) {
+
+	const uri = "http://fakehost/2015/03/story/2"
+
+	testCases := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			"link rel=next",
+			`<html><head><link rel="next" href="/2015/03/story/3" /></head><body><a href="/2015/03/story/1">1</a></body></html>`,
+			"http://fakehost/2015/03/story/3",
+		},
+		{
+			"pagination",
+			`<html><body><div class="pager"><a href="/2015/03/story/1">« Prev</a> <a href="/2015/03/story/1">1</a> <a href="/2015/03/story/3">3</a> ` +
+				`<a href="/2015/03/story/3" class="next">Next page</a></div></body></html>`,
+			"http://fakehost/2015/03/story/3",
+		},
+		{
+			"query parameter",
+			`<html><body><a href="/2015/03/story/2?page=3">Continue reading »</a></body></html>`,
+			"http://fakehost/2015/03/story/2?page=3",
+		},
+		{
+			"previous page only",
+			`<html><body><a href="/2015/03/story/1">« Previous</a></body></html>`,
+			"",
+		},
+		{
+			"other site",
+			`<html><body><a href="http://otherhost/2015/03/story/3">Next »</a></body></html>`,
+			"",
+		},
+		{
+			"link rel=next to other site",
+			`<html><head><link rel="next" href="http://169.254.169.254/latest/meta-data/" /></head><body><a href="/2015/03/story/3">Next »</a></body></html>`,
+			"http://fakehost/2015/03/story/3",
+		},
+		{
+			"other links",
+			`<html><body><a href="/2015/03/other-story">Next story</a> <a href="/2015/03/story/2#comments">12 comments</a></body></html>`,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader, err := New(tc.html, uri)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, reader.findNextPageLink(make(map[string]bool)))
+		})
+	}
+}
+
+func TestMultiPageArticle(t *testing.T) {
+
+	var parse = func(t *testing.T, uri string, opts ...Option) *Result {
+		body, contentType, err := (&HTTPFetcher{}).Fetch(context.Background(), mustParseURL(t, uri))
+		assert.NoError(t, err)
+		defer body.Close()
+		reader, err := NewFromReader(body, mustParseURL(t, uri), contentType, opts...)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should stitch the pages of the article", func(t *testing.T) {
+		var server, requested = newPagedArticleServer(3)
+		defer server.Close()
+
+		var result = parse(t, server.URL+"/story/1", PageFetcher(&HTTPFetcher{Client: server.Client()}))
+		for n := 1; n <= 3; n++ {
+			assert.Contains(t, result.HTMLContent, fmt.Sprintf(`<div id="readability-page-%d" class="page">`, n))
+			assert.Contains(t, result.TextContent, fmt.Sprintf("This is the paragraph of the page %d of the story", n))
+			// The URLs are resolved against their page.
+			assert.Contains(t, result.HTMLContent, fmt.Sprintf(`src="%s/story/image-%d.jpg"`, server.URL, n))
+		}
+		assert.Equal(t, 3, strings.Count(result.HTMLContent, `class="page"`))
+		assert.NotContains(t, result.TextContent, "Next")
+		// The last page links back to the first one, which is not fetched again.
+		assert.Equal(t, []string{"/story/1", "/story/2", "/story/3"}, *requested)
+	})
+
+	t.Run("should stop at the maximum number of pages", func(t *testing.T) {
+		var server, requested = newPagedArticleServer(5)
+		defer server.Close()
+
+		var result = parse(t, server.URL+"/story/1", PageFetcher(&HTTPFetcher{Client: server.Client()}), MaxPages(2))
+		assert.Equal(t, 2, strings.Count(result.HTMLContent, `class="page"`))
+		assert.Equal(t, []string{"/story/1", "/story/2"}, *requested)
+	})
+
+	t.Run("should keep the first page when the next one cannot be fetched", func(t *testing.T) {
+		var server, _ = newPagedArticleServer(3)
+		defer server.Close()
+
+		var result = parse(t, server.URL+"/story/1", PageFetcher(failingFetcher{}))
+		assert.Equal(t, 1, strings.Count(result.HTMLContent, `class="page"`))
+		assert.Contains(t, result.TextContent, "page 1 of the story")
+	})
+
+	t.Run("should not follow pages without fetcher", func(t *testing.T) {
+		var server, requested = newPagedArticleServer(3)
+		defer server.Close()
+
+		var result = parse(t, server.URL+"/story/1")
+		assert.Equal(t, 1, strings.Count(result.HTMLContent, `class="page"`))
+		assert.Equal(t, []string{"/story/1"}, *requested)
+	})
+}
@@ -22,15 +22,19 @@
 package readability
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"math"
 	"net/url"
-	"reflect"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
 )
 
 const (
@@ -44,8 +48,25 @@ const (
 	// tight the competition is among candidates.
 	defaultNTopCandidates = 5
 
+	// The default maximum number of pages of a multi-page article, including the first one.
+	defaultMaxPages = 10
+
 	// The default number of chars an article must have in order to return a result
 	defaultCharThreshold = 500
+
+	// The score added to elements annotated as the articleBody with microdata or RDFa.
+	articleBodyBonus = 25
+)
+
+// Stage identifies a step of the parsing workflow run by ParseContext.
+type Stage string
+
+const (
+	StagePrepDocument       Stage = "prepDocument"
+	StageGrabArticle        Stage = "grabArticle"
+	StagePrepArticle        Stage = "prepArticle"
+	StagePostProcessContent Stage = "postProcessContent"
+	StageFetchPages         Stage = "fetchPages"
 )
 
 var (
@@ -85,7 +106,10 @@ type Readability struct {
 	articleDir      string
 	articleSiteName string
 	articleLang     string
+	links           []Link
+	encoding        string
 	attempts        []*attempt
+	logger          *slog.Logger
 }
 
 type attempt struct {
@@ -101,10 +125,11 @@ type attempt struct {
 //   - this.classesToPreseve
 //   - options.keepClasses
 //   - options.serializer
+//   - options.logger
 func New(htmlSource, uri string, opts ...Option) (*Readability, error) {
 
 	if htmlSource == "" {
-		return nil, fmt.Errorf("first argument to Readability constructor should be a HTML document")
+		return nil, ErrEmptyDocument
 	}
 
 	r := &Readability{
@@ -116,9 +141,11 @@ func New(htmlSource, uri string, opts ...Option) (*Readability, error) {
 		opt(r.options)
 	}
 
-	r.doc = newDOMParser().parse(htmlSource, uri)
+	r.logger = r.options.logger.With(slog.String("uri", uri))
+
+	r.doc = newDOMParser(Logger(r.logger)).parse(htmlSource, uri)
 	if r.doc == nil || r.doc.Body == nil {
-		return nil, fmt.Errorf("cannot parse doc")
+		return nil, ErrNoBody
 	}
 
 	// Start with all flags set
@@ -127,6 +154,57 @@ func New(htmlSource, uri string, opts ...Option) (*Readability, error) {
 	return r, nil
 }
 
+// NewFromReader is like New but reads the raw bytes of the document from r.
+// The character encoding is detected from the BOM, the given Content-Type
+// header value and the <meta charset> or http-equiv declarations found
+// at the beginning of the document, in this order. The document is then
+// transcoded to UTF-8 before being tokenized.
+// The detected encoding is reported in Result.Encoding.
+func NewFromReader(r io.Reader, pageURL *url.URL, contentType string, opts ...Option) (*Readability, error) {
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read document: %w", err)
+	}
+
+	htmlSource, name, err := decodeToUTF8(raw, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	var uri string
+	if pageURL != nil {
+		uri = pageURL.String()
+	}
+
+	reader, err := New(htmlSource, uri, opts...)
+	if err != nil {
+		return nil, err
+	}
+	reader.encoding = name
+	return reader, nil
+}
+
+// Detects the character encoding of the given document and converts it to UTF-8.
+// Returns the decoded document and the canonical name of the detected encoding.
+func decodeToUTF8(raw []byte, contentType string) (string, string, error) {
+	enc, name, certain := charset.DetermineEncoding(raw, contentType)
+	if !certain && utf8.Valid(raw) {
+		// Without declaration, the encoding is guessed from the first 1024 bytes only:
+		// pure ASCII is taken for windows-1252, even when UTF-8 follows.
+		name = "utf-8"
+	}
+	if name != "utf-8" {
+		decoded, err := enc.NewDecoder().Bytes(raw)
+		if err != nil {
+			return "", name, fmt.Errorf("cannot decode document from %s: %w", name, err)
+		}
+		raw = decoded
+	}
+	// The BOM, if any, has already been used to detect the encoding.
+	return strings.TrimPrefix(string(raw), "\uFEFF"), name, nil
+}
+
 type Result struct {
 	// article title
 	Title string
@@ -134,33 +212,119 @@ type Result struct {
 	HTMLContent string
 	// text content of the article, with all the HTML tags removed
 	TextContent string
+	// processed article content, as serialized in HTMLContent, for the renderers
+	// of other formats
+	Content *Node
 	// length of an article, in characters (runes)
 	Length int
+	// number of words of the article, without code blocks and figure captions.
+	// The characters of Chinese, Japanese and Thai are counted as words.
+	WordCount int
+	// estimated time needed to read the article
+	ReadingTime time.Duration
+	// headings of the article, nested by level
+	Outline []Heading
+	// links found in the article content, in order
+	Links []Link
+	// images, videos and sounds of the article content, in order
+	Media []MediaItem
 	// article description, or short excerpt from the content
 	Excerpt string
-	// author metadata
+	// author metadata, as a single string
 	Byline string
+	// authors, from the metadata or the byline
+	Authors []Author
 	// content direction
 	Dir string
 	// name of the site
 	SiteName string
-	// content language
+	// content language, as a BCP 47 tag: declared by the document or,
+	// as a last resort, guessed from the text content
 	Lang string
-	// published time
+	// confidence in the content language, between 0 and 1: 1 if the language
+	// is declared by the document, less if it was guessed
+	LangConfidence float64
+	// published time, as found in the metadata
 	PublishedTime string
+	// published time parsed from the metadata or, as a fallback, from the
+	// <time datetime> elements of the article or the date in the document URL
+	Published time.Time
+	// modified time, as found in the metadata
+	ModifiedTime string
+	// modified time parsed from the metadata
+	Modified time.Time
+	// character encoding of the source document, as detected by NewFromReader
+	Encoding string
+	// lead image, from the metadata or, as a fallback, from the article content
+	Image *Image
+	// canonical URL of the article
+	CanonicalURL string
+	// URL of the site icon
+	Favicon string
+	// URLs of the translations of the article, by language
+	Alternates map[string]string
+	// tags and keywords, without duplicates
+	Tags []string
+	// section of the site the article belongs to
+	Section string
+	// Schema.org article object the metadata was extracted from, if any,
+	// with @graph references resolved
+	JSONLD map[string]interface{}
+	// bibliographic metadata, for scholarly articles only
+	Citation *Citation
 }
 
 // Run any post-process modifications to article content as necessary.
-func (r *Readability) postProcessContent(articleContent *Node) {
+func (r *Readability) postProcessContent(ctx context.Context, articleContent *Node) error {
 	// Readability cannot open relative uris so we convert them to absolute uris.
 	r.fixRelativeUris(articleContent)
 
-	r.simplifyNestedElements(articleContent)
+	if err := r.simplifyNestedElements(ctx, articleContent); err != nil {
+		return err
+	}
 
 	if !r.options.keepClasses {
 		// Remove classes.
 		r.cleanClasses(articleContent)
 	}
+
+	if r.options.headingIds {
+		r.setHeadingIds(articleContent)
+	}
+
+	if r.options.policy != nil {
+		r.options.policy.sanitize(articleContent)
+		r.links = slices.DeleteFunc(r.links, func(link Link) bool {
+			return !r.options.policy.allowsURL(link.Href)
+		})
+	}
+	return checkContext(ctx, StagePostProcessContent)
+}
+
+// Runs the given stage, checking for cancellation before starting it.
+func (r *Readability) runStage(ctx context.Context, stage Stage, fn func(context.Context) error) error {
+	if err := checkContext(ctx, stage); err != nil {
+		return err
+	}
+	stageCtx, cancel := r.stageContext(ctx, stage)
+	defer cancel()
+	return fn(stageCtx)
+}
+
+// Returns a copy of ctx bounded by the timeout configured for the given stage, if any.
+func (r *Readability) stageContext(ctx context.Context, stage Stage) (context.Context, context.CancelFunc) {
+	if timeout := r.options.stageTimeouts[stage]; timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// Returns the error of the given context, if it is done, wrapped with the name of the current stage.
+func checkContext(ctx context.Context, stage Stage) error {
+	if err := ctx.Err(); err != nil {
+		return &StageError{Stage: stage, Err: err}
+	}
+	return nil
 }
 
 // Iterates over a NodeList, calls `filterFn` for each node and removes node
@@ -173,7 +337,7 @@ func (r *Readability) removeNodes(nodeList []*Node, filterFn func(n *Node) bool)
 		if parentNode != nil {
 			if filterFn == nil || filterFn(node) {
 				if _, err := parentNode.RemoveChild(node); err != nil {
-					slog.Error("cannot remove child", slog.String("err", err.Error()))
+					r.logger.Error("cannot remove child", slog.String("err", err.Error()))
 				}
 			}
 		}
@@ -231,6 +395,10 @@ func (r *Readability) getAllNodesWithTag(n *Node, tagNames ...string) []*Node {
 // the classesToPreserve array from the options object.
 func (r *Readability) cleanClasses(n *Node) {
 	className := n.GetAttribute("class")
+	if n.TagName == "PRE" || n.TagName == "CODE" {
+		// The renderers still need the language of code blocks, e.g. "go" for "language-go".
+		n.codeLanguage = anyOf(languageFromClass(className), n.codeLanguage)
+	}
 	if className != "" {
 		className = strings.Join(filter(r.preserve, multipleWhitespaces.Split(className, -1)...), " ")
 	}
@@ -260,86 +428,92 @@ func filter(filterFn func(string) bool, strs ...string) []string {
 	return filtered
 }
 
-// Converts each <a> and <img> uri in the given element to an absolute URI,
-// ignoring #ref URIs.
-func (r *Readability) fixRelativeUris(articleContent *Node) {
+// Converts the given uri to an absolute URI, resolving it against the base URI
+// of the document. Hash links are left alone if the base URI matches the document URI.
+func (r *Readability) toAbsoluteURI(uri string) string {
 	baseURI := r.doc.getBaseURI()
 	documentURI := r.doc.DocumentURI
 
-	var toAbsoluteURI = func(uri string) string {
-		uri = strings.TrimSpace(uri)
-		// Leave hash links alone if the base URI matches the document URI:
-		if baseURI == documentURI && []rune(uri)[0] == '#' {
-			return uri
-		}
-		base, err := url.Parse(baseURI)
-		if err != nil {
-			// Something went wrong, just return the original:
-			return uri
-		}
-		ref, err := url.Parse(uri)
-		if err != nil {
-			// Something went wrong, just return the original:
-			return uri
-		}
-		u := base.ResolveReference(ref)
-		var abs string
-		if u.Scheme != "" {
-			abs += u.Scheme
-			if strings.HasPrefix(u.Scheme, "http") {
-				abs += "://"
-			} else {
-				abs += ":"
-			}
-		}
-		abs += strings.ToLower(u.Host)
-
-		var b, a string
-		if strings.Contains(uri, "?") {
-			before, _, _ := strings.Cut(uri, "?")
-			b = before
-		} else if strings.Contains(uri, "#") {
-			before, after, _ := strings.Cut(uri, "#")
-			b = before
-			a = after
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return uri
+	}
+	// Leave hash links alone if the base URI matches the document URI:
+	if baseURI == documentURI && []rune(uri)[0] == '#' {
+		return uri
+	}
+	base, err := url.Parse(baseURI)
+	if err != nil {
+		// Something went wrong, just return the original:
+		return uri
+	}
+	ref, err := url.Parse(uri)
+	if err != nil {
+		// Something went wrong, just return the original:
+		return uri
+	}
+	u := base.ResolveReference(ref)
+	var abs string
+	if u.Scheme != "" {
+		abs += u.Scheme
+		if strings.HasPrefix(u.Scheme, "http") {
+			abs += "://"
 		} else {
-			b = uri
+			abs += ":"
 		}
+	}
+	abs += strings.ToLower(u.Host)
 
-		if u.Path != "" {
-			p := u.Path
-			if strings.Contains(uri, "%") {
-				if strings.HasPrefix(uri, "//") {
-					p = doubleForwardSlashes.ReplaceAllString(b, "")
-				} else {
-					p = strings.ReplaceAll(b, abs, "")
-				}
-			}
-			abs += strings.ReplaceAll(p, "/C|/", "/C:/")
-		} else if u.Opaque != "" {
-			abs += u.Opaque
-		} else {
-			abs += "/"
-		}
-		if u.RawQuery != "" {
-			abs += "?" + u.RawQuery
-		}
-		if u.Fragment != "" {
-			if strings.Contains(a, "%") {
-				abs += "#" + a
+	var b, a string
+	if strings.Contains(uri, "?") {
+		before, _, _ := strings.Cut(uri, "?")
+		b = before
+	} else if strings.Contains(uri, "#") {
+		before, after, _ := strings.Cut(uri, "#")
+		b = before
+		a = after
+	} else {
+		b = uri
+	}
+
+	if u.Path != "" {
+		p := u.Path
+		if strings.Contains(uri, "%") {
+			if strings.HasPrefix(uri, "//") {
+				p = doubleForwardSlashes.ReplaceAllString(b, "")
 			} else {
-				abs += "#" + u.Fragment
+				p = strings.ReplaceAll(b, abs, "")
 			}
 		}
-		if strings.HasSuffix(uri, "#") && !strings.HasSuffix(abs, "#") {
-			abs += "#"
-		}
-		if strings.HasSuffix(uri, "?") && !strings.HasSuffix(abs, "?") {
-			abs += "?"
+		abs += strings.ReplaceAll(p, "/C|/", "/C:/")
+	} else if u.Opaque != "" {
+		abs += u.Opaque
+	} else {
+		abs += "/"
+	}
+	if u.RawQuery != "" {
+		abs += "?" + u.RawQuery
+	}
+	if u.Fragment != "" {
+		if strings.Contains(a, "%") {
+			abs += "#" + a
+		} else {
+			abs += "#" + u.Fragment
 		}
-		return abs
 	}
+	if strings.HasSuffix(uri, "#") && !strings.HasSuffix(abs, "#") {
+		abs += "#"
+	}
+	if strings.HasSuffix(uri, "?") && !strings.HasSuffix(abs, "?") {
+		abs += "?"
+	}
+	return abs
+}
 
+// Converts each <a> and <img> uri in the given element to an absolute URI,
+// ignoring #ref URIs. The links found are collected in r.links.
+func (r *Readability) fixRelativeUris(articleContent *Node) {
+	r.links = nil
 	var links = r.getAllNodesWithTag(articleContent, "a")
 	for _, link := range links {
 		var href = link.GetAttribute("href")
@@ -347,27 +521,21 @@ func (r *Readability) fixRelativeUris(articleContent *Node) {
 			// Remove links with javascript: URIs, since
 			// they won't work after scripts have been removed from the page.
 			if strings.HasPrefix(href, "javascript:") {
-				// if the link only contains simple text content, it can be converted to a text node
-				if len(link.ChildNodes) == 1 && link.ChildNodes[0].NodeType == textNode {
-					var text = r.doc.createTextNode(link.GetTextContent())
-					link.ParentNode.ReplaceChild(text, link)
-				} else {
-					// if the link has multiple children, they should all be preserved
-					var container = r.doc.createElementNode("span")
-					for link.FirstChild() != nil {
-						container.AppendChild(link.FirstChild())
-					}
-					link.ParentNode.ReplaceChild(container, link)
-				}
+				r.unwrapLink(link)
+			} else if r.options.stripNofollowLinks && isNofollowLink(link) {
+				r.unwrapLink(link)
 			} else {
 				if strings.Contains(href, ",%20") {
 					var hrefs []string
 					for _, link := range strings.Split(href, ",%20") {
-						hrefs = append(hrefs, toAbsoluteURI(link))
+						hrefs = append(hrefs, r.toAbsoluteURI(link))
 					}
 					link.SetAttribute("href", strings.Join(hrefs, ",%20"))
 				} else {
-					link.SetAttribute("href", toAbsoluteURI(href))
+					link.SetAttribute("href", r.toAbsoluteURI(href))
+				}
+				if l := r.newLink(link); l != nil {
+					r.links = append(r.links, *l)
 				}
 			}
 		}
@@ -380,18 +548,18 @@ func (r *Readability) fixRelativeUris(articleContent *Node) {
 	for _, media := range medias {
 		var src = media.GetAttribute("src")
 		if src != "" {
-			media.SetAttribute("src", toAbsoluteURI(src))
+			media.SetAttribute("src", r.toAbsoluteURI(src))
 		}
 		var poster = media.GetAttribute("poster")
 		if poster != "" {
-			media.SetAttribute("poster", toAbsoluteURI(poster))
+			media.SetAttribute("poster", r.toAbsoluteURI(poster))
 		}
 		var srcset = media.GetAttribute("srcset")
 		if srcset != "" {
 			submatches := srcsetUrl.FindAllStringSubmatch(srcset, -1)
 			var newSrcset []string
 			for _, submatch := range submatches {
-				newSrcset = append(newSrcset, toAbsoluteURI(submatch[1])+submatch[2]+submatch[3])
+				newSrcset = append(newSrcset, r.toAbsoluteURI(submatch[1])+submatch[2]+submatch[3])
 			}
 			if !strings.Contains(srcset, ", ") {
 				media.SetAttribute("srcset", strings.Join(newSrcset, ""))
@@ -402,9 +570,12 @@ func (r *Readability) fixRelativeUris(articleContent *Node) {
 	}
 }
 
-func (r *Readability) simplifyNestedElements(articleContent *Node) {
+func (r *Readability) simplifyNestedElements(ctx context.Context, articleContent *Node) error {
 	var node = articleContent
 	for node != nil {
+		if err := checkContext(ctx, StagePostProcessContent); err != nil {
+			return err
+		}
 		if node.ParentNode != nil && slices.Contains([]string{"DIV", "SECTION"}, node.TagName) && !strings.HasPrefix(node.GetId(), "readability") {
 			if r.isElementWithoutContent(node) {
 				node = r.removeAndGetNext(node)
@@ -421,6 +592,7 @@ func (r *Readability) simplifyNestedElements(articleContent *Node) {
 		}
 		node = r.getNextNode(node, false)
 	}
+	return nil
 }
 
 // Get the article title as an H1.
@@ -504,16 +676,19 @@ func (r *Readability) getArticleTitle() string {
 
 // Prepare the HTML document for readability to scrape it.
 // This includes things like stripping javascript, CSS, and handling terrible markup.
-func (r *Readability) prepDocument() {
+func (r *Readability) prepDocument(ctx context.Context) error {
 	var doc = r.doc
 	// Remove all style tags in head
 	r.removeNodes(r.getAllNodesWithTag(doc, "style"), nil)
 
 	if doc.Body != nil {
-		r.replaceBrs(doc.Body)
+		if err := r.replaceBrs(ctx, doc.Body); err != nil {
+			return err
+		}
 	}
 
 	r.replaceNodeTags(r.getAllNodesWithTag(doc, "font"), "SPAN")
+	return checkContext(ctx, StagePrepDocument)
 }
 
 // Finds the next node, starting from the given node, and ignoring
@@ -537,9 +712,13 @@ func (r *Readability) nextNode(n *Node) *Node {
 // will become:
 //
 //	<div>foo<br>bar<p>abc</p></div>
-func (r *Readability) replaceBrs(n *Node) {
+func (r *Readability) replaceBrs(ctx context.Context, n *Node) error {
 
 	for _, br := range r.getAllNodesWithTag(n, "br") {
+		if err := checkContext(ctx, StagePrepDocument); err != nil {
+			return err
+		}
+
 		var next = br.NextSibling
 
 		// Whether 2 or more <br> elements have been found and replaced with a
@@ -553,7 +732,7 @@ func (r *Readability) replaceBrs(n *Node) {
 			replaced = true
 			var brSibling = next.NextSibling
 			if _, err := next.ParentNode.RemoveChild(next); err != nil {
-				slog.Error("cannot remove child", slog.String("err", err.Error()))
+				r.logger.Error("cannot remove child", slog.String("err", err.Error()))
 			}
 			next = brSibling
 		}
@@ -587,7 +766,7 @@ func (r *Readability) replaceBrs(n *Node) {
 
 			for p.LastChild() != nil && r.isWhitespace(p.LastChild()) {
 				if _, err := p.RemoveChild(p.LastChild()); err != nil {
-					slog.Error("cannot remove child", slog.String("err", err.Error()))
+					r.logger.Error("cannot remove child", slog.String("err", err.Error()))
 				}
 			}
 
@@ -596,10 +775,11 @@ func (r *Readability) replaceBrs(n *Node) {
 			}
 		}
 	}
+	return nil
 }
 
 func (r *Readability) setNodeTag(n *Node, tag string) *Node {
-	slog.Debug("setNodeTag", "node", n, "tag", tag)
+	r.logger.Debug("setNodeTag", "node", n, "tag", tag)
 	n.LocalName = strings.ToLower(tag)
 	n.TagName = strings.ToUpper(tag)
 	return n
@@ -607,7 +787,7 @@ func (r *Readability) setNodeTag(n *Node, tag string) *Node {
 
 // Prepare the article node for display. Clean out any inline styles,
 // iframes, forms, strip extraneous <p> tags, etc.
-func (r *Readability) prepArticle(articleContent *Node) {
+func (r *Readability) prepArticle(ctx context.Context, articleContent *Node) error {
 	r.cleanStyles(articleContent)
 
 	// Check for data tables before we continue, to avoid removing items in
@@ -617,6 +797,10 @@ func (r *Readability) prepArticle(articleContent *Node) {
 
 	r.fixLazyImages(articleContent)
 
+	if err := checkContext(ctx, StagePrepArticle); err != nil {
+		return err
+	}
+
 	// Clean out junk from the article content
 	r.cleanConditionally(articleContent, "form")
 	r.cleanConditionally(articleContent, "fieldset")
@@ -643,12 +827,20 @@ func (r *Readability) prepArticle(articleContent *Node) {
 	r.clean(articleContent, "button")
 	r.cleanHeaders(articleContent)
 
+	if err := checkContext(ctx, StagePrepArticle); err != nil {
+		return err
+	}
+
 	// Do these last as the previous stuff may have removed junk
 	// that will affect these
 	r.cleanConditionally(articleContent, "table")
 	r.cleanConditionally(articleContent, "ul")
 	r.cleanConditionally(articleContent, "div")
 
+	if err := checkContext(ctx, StagePrepArticle); err != nil {
+		return err
+	}
+
 	// replace H1 with H2 as H1 should be only title that is displayed separately
 	r.replaceNodeTags(r.getAllNodesWithTag(articleContent, "h1"), "h2")
 
@@ -667,7 +859,7 @@ func (r *Readability) prepArticle(articleContent *Node) {
 		var next = r.nextNode(br.NextSibling)
 		if next != nil && next.TagName == "P" {
 			if _, err := br.ParentNode.RemoveChild(br); err != nil {
-				slog.Error("cannot remove child", slog.String("err", err.Error()))
+				r.logger.Error("cannot remove child", slog.String("err", err.Error()))
 			}
 		}
 	}
@@ -691,6 +883,7 @@ func (r *Readability) prepArticle(articleContent *Node) {
 			}
 		}
 	}
+	return checkContext(ctx, StagePrepArticle)
 }
 
 // Initialize a node with the readability object. Also checks the
@@ -716,12 +909,17 @@ func (r *Readability) initializeNode(n *Node) {
 	}
 
 	n.ReadabilityNode.ContentScore += r.getClassWeight(n)
+
+	// The article content can be annotated as such with microdata or RDFa.
+	if !r.options.disableMicrodata && isArticleBody(n) {
+		n.ReadabilityNode.ContentScore += articleBodyBonus
+	}
 }
 
 func (r *Readability) removeAndGetNext(n *Node) *Node {
 	var nextNode = r.getNextNode(n, true)
 	if _, err := n.ParentNode.RemoveChild(n); err != nil {
-		slog.Error("cannot remove child", slog.String("err", err.Error()))
+		r.logger.Error("cannot remove child", slog.String("err", err.Error()))
 	}
 	return nextNode
 }
@@ -802,9 +1000,9 @@ func (r *Readability) getNodeAncestors(n *Node, maxDepth int) []*Node {
 
 // Using a variety of metrics (content score, classname, element types), find the content that is
 // most likely to be the stuff a user wants to read. Then return it wrapped up in a div.
-func (r *Readability) grabArticle(page *Node) *Node {
+func (r *Readability) grabArticle(ctx context.Context, page *Node) (*Node, error) {
 
-	slog.Debug("**** grabArticle ****")
+	r.logger.Debug("**** grabArticle ****")
 	var doc = r.doc
 
 	var isPaging bool
@@ -817,14 +1015,19 @@ func (r *Readability) grabArticle(page *Node) *Node {
 
 	// We can't grab an article if we don't have a page!
 	if page == nil {
-		slog.Debug("No body found in document. Abort.")
-		return nil
+		r.logger.Debug("No body found in document. Abort.")
+		return nil, nil
 	}
 
 	var pageCacheHtml = page.GetInnerHTML()
 
 	for {
-		slog.Debug("Starting grabArticle loop")
+		if err := checkContext(ctx, StageGrabArticle); err != nil {
+			return nil, err
+		}
+
+		var logger = r.logger.With(slog.Int("attempt", len(r.attempts)+1))
+		logger.Debug("Starting grabArticle loop")
 		var stripUnlikelyCandidates = r.flagIsActive(flagStripUnlikelys)
 
 		// First, node prepping. Trash nodes that look cruddy (like ones with the
@@ -836,8 +1039,11 @@ func (r *Readability) grabArticle(page *Node) *Node {
 		var shouldRemoveTitleHeader bool = true
 
 		for n != nil {
+			if err := checkContext(ctx, StageGrabArticle); err != nil {
+				return nil, err
+			}
 
-			slog.Debug("elementsToScore", "nodeText", n.GetTextContent())
+			logger.Debug("elementsToScore", "nodeText", n.GetTextContent())
 
 			if n.TagName == "HTML" {
 				r.articleLang = n.GetAttribute("lang")
@@ -846,7 +1052,7 @@ func (r *Readability) grabArticle(page *Node) *Node {
 			var matchString = n.GetClassName() + " " + n.GetId()
 
 			if !isProbablyVisible(n) {
-				slog.Debug("Removing hidden node - " + matchString)
+				logger.Debug("Removing hidden node - " + matchString)
 				n = r.removeAndGetNext(n)
 				continue
 			}
@@ -864,7 +1070,7 @@ func (r *Readability) grabArticle(page *Node) *Node {
 			}
 
 			if shouldRemoveTitleHeader && r.headerDuplicatesTitle(n) {
-				slog.Debug("Removing header:", "textContent", strings.TrimSpace(n.GetTextContent()), "articleTitle", strings.TrimSpace(r.articleTitle))
+				logger.Debug("Removing header:", "textContent", strings.TrimSpace(n.GetTextContent()), "articleTitle", strings.TrimSpace(r.articleTitle))
 				shouldRemoveTitleHeader = false
 				n = r.removeAndGetNext(n)
 				continue
@@ -878,14 +1084,14 @@ func (r *Readability) grabArticle(page *Node) *Node {
 					!r.hasAncestorTag(n, "code", 3, nil) &&
 					n.TagName != "BODY" &&
 					n.TagName != "A" {
-					slog.Debug("Removing unlikely candidate", "matchString", matchString)
+					logger.Debug("Removing unlikely candidate", "matchString", matchString)
 					n = r.removeAndGetNext(n)
 					continue
 				}
 			}
 
 			if slices.Contains(unlinkelyRoles, n.GetAttribute("role")) {
-				slog.Debug("Removing content", "role", n.GetAttribute("role"), "matchString", matchString)
+				logger.Debug("Removing content", "role", n.GetAttribute("role"), "matchString", matchString)
 				n = r.removeAndGetNext(n)
 				continue
 			}
@@ -921,7 +1127,7 @@ func (r *Readability) grabArticle(page *Node) *Node {
 					} else if p != nil {
 						for p.LastChild() != nil && r.isWhitespace(p.LastChild()) {
 							if _, err := p.RemoveChild(p.LastChild()); err != nil {
-								slog.Error("cannot remove child", slog.String("err", err.Error()))
+								logger.Error("cannot remove child", slog.String("err", err.Error()))
 							}
 						}
 						p = nil
@@ -952,6 +1158,10 @@ func (r *Readability) grabArticle(page *Node) *Node {
 
 		var candidates []*Node
 		for _, elementToScore := range elementsToScore {
+			if err := checkContext(ctx, StageGrabArticle); err != nil {
+				return nil, err
+			}
+
 			if elementToScore.ParentNode == nil {
 				continue
 			}
@@ -1002,7 +1212,7 @@ func (r *Readability) grabArticle(page *Node) *Node {
 					scoreDivider = level * 3
 				}
 				ancestor.ReadabilityNode.ContentScore += contentScore / float64(scoreDivider)
-				slog.Debug("assigned score", "ancestor", ancestor.GetTextContent(), "score", ancestor.ReadabilityNode.ContentScore)
+				logger.Debug("assigned score", "ancestor", ancestor.GetTextContent(), "score", ancestor.ReadabilityNode.ContentScore)
 			}
 		}
 
@@ -1018,7 +1228,7 @@ func (r *Readability) grabArticle(page *Node) *Node {
 			var candidateScore = candidate.ReadabilityNode.ContentScore * (1 - r.getLinkDensity(candidate))
 			candidate.ReadabilityNode.ContentScore = candidateScore
 
-			slog.Debug("grabArticle", "candidate", candidate.GetTextContent(), "scaled-score", candidateScore)
+			logger.Debug("grabArticle", "candidate", candidate.GetTextContent(), "scaled-score", candidateScore)
 
 			for t := 0; t < r.options.nbTopCandidates; t++ {
 				var aTopCandidate *Node
@@ -1053,7 +1263,7 @@ func (r *Readability) grabArticle(page *Node) *Node {
 			// Move everything (not just elements, also text nodes etc.) into the container
 			// so we even include text directly in the body:
 			for page.FirstChild() != nil {
-				slog.Debug("Moving out:", "child", page.FirstChild().nodeName)
+				logger.Debug("Moving out:", "child", page.FirstChild().nodeName)
 				topCandidate.AppendChild(page.FirstChild())
 			}
 
@@ -1148,10 +1358,14 @@ func (r *Readability) grabArticle(page *Node) *Node {
 		var siblings = parentOfTopCandidate.Children
 		var sl = len(siblings)
 		for s := 0; s < sl; s++ {
+			if err := checkContext(ctx, StageGrabArticle); err != nil {
+				return nil, err
+			}
+
 			var sibling = siblings[s]
 			var append = false
 
-			slog.Debug("Looking at sibling node:", "sibling", sibling.GetTextContent(), "score", sibling.ReadabilityNode)
+			logger.Debug("Looking at sibling node:", "sibling", sibling.GetTextContent(), "score", sibling.ReadabilityNode)
 
 			if sibling == topCandidate {
 				append = true
@@ -1179,11 +1393,11 @@ func (r *Readability) grabArticle(page *Node) *Node {
 			}
 
 			if append {
-				slog.Debug("appending", "node", sibling.GetTextContent())
+				logger.Debug("appending", "node", sibling.GetTextContent())
 				if !slices.Contains(alterToDiveExceptions, sibling.GetNodeName()) {
 					// We have a node that isn't a common block level element, like a form or td tag.
 					// Turn it into a div so it doesn't get filtered out later by accident.
-					slog.Debug("altering", "node", sibling.GetTextContent())
+					logger.Debug("altering", "node", sibling.GetTextContent())
 
 					sibling = r.setNodeTag(sibling, "DIV")
 				}
@@ -1201,10 +1415,15 @@ func (r *Readability) grabArticle(page *Node) *Node {
 			}
 		}
 
-		slog.Debug("Article content pre-prep", "innerHTML", articleContent.GetInnerHTML())
+		logger.Debug("Article content pre-prep", "innerHTML", articleContent.GetInnerHTML())
 		// So we have all of the content that we need. Now we clean it up for presentation.
-		r.prepArticle(articleContent)
-		slog.Debug("Article content post-prep", "innerHTML", articleContent.GetInnerHTML())
+		prepCtx, cancel := r.stageContext(ctx, StagePrepArticle)
+		err := r.prepArticle(prepCtx, articleContent)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		logger.Debug("Article content post-prep", "innerHTML", articleContent.GetInnerHTML())
 
 		if neededToCreateTopCandidate {
 			// We already created a fake div thing, and there wouldn't have been any siblings left
@@ -1223,7 +1442,7 @@ func (r *Readability) grabArticle(page *Node) *Node {
 			articleContent.AppendChild(div)
 		}
 
-		slog.Debug("Article content after paging", "innerHTML", articleContent.GetInnerHTML())
+		logger.Debug("Article content after paging", "innerHTML", articleContent.GetInnerHTML())
 
 		var parseSuccessful = true
 
@@ -1249,14 +1468,14 @@ func (r *Readability) grabArticle(page *Node) *Node {
 			} else {
 				r.attempts = append(r.attempts, &attempt{articleContent: articleContent, textLength: textLength})
 				// No luck after removing flags, just return the longest text we found during the different loops
-				slices.SortFunc(r.attempts, func(a, b *attempt) int {
-					return b.textLength - a.textLength
+				var longest = slices.MaxFunc(r.attempts, func(a, b *attempt) int {
+					return a.textLength - b.textLength
 				})
 
-				if r.attempts[0].textLength == 0 {
-					return nil
+				if longest.textLength == 0 {
+					return nil, nil
 				}
-				articleContent = r.attempts[0].articleContent
+				articleContent = longest.articleContent
 				parseSuccessful = true
 			}
 		}
@@ -1276,7 +1495,7 @@ func (r *Readability) grabArticle(page *Node) *Node {
 				}
 				return false
 			})
-			return articleContent
+			return articleContent, nil
 		}
 	}
 }
@@ -1296,7 +1515,7 @@ func (r *Readability) unescapeHtmlEntities(str string) string {
 	}
 	decoded, err := decodeHTML(str)
 	if err != nil {
-		slog.Error(err.Error())
+		r.logger.Error(err.Error())
 	}
 	return decoded
 }
@@ -1308,149 +1527,53 @@ type metadata struct {
 	siteName      string
 	datePublished string
 	publishedTime string
-}
-
-// Try to extract metadata from JSON-LD object.
-// For now, only Schema.org objects of type Article or its subtypes are supported.
-func (r *Readability) getJSONLD(doc *Node) *metadata {
-
-	var scripts = r.getAllNodesWithTag(doc, "script")
-
-	var meta *metadata
-
-	for _, jsonLdElement := range scripts {
-		if meta == nil && jsonLdElement.GetAttribute("type") == "application/ld+json" {
-			// Strip CDATA markers if present
-			var content = cdata.ReplaceAllString(jsonLdElement.GetTextContent(), "")
-			var parsed map[string]interface{}
-			if err := json.Unmarshal([]byte(content), &parsed); err != nil {
-				slog.Error("cannot unmarshal JSON-LD element content", "err", err)
-				continue
-			}
-
-			ctx, ctxFound := parsed["@context"]
-			if !ctxFound || !schemaUrl.MatchString(ctx.(string)) {
-				continue
-			}
-
-			_, typeFound := parsed["@type"]
-			graph, graphFound := parsed["@graph"]
-			if !typeFound || graphFound {
-				graphArr, isArray := graph.([]interface{})
-				if isArray {
-					for _, el := range graphArr {
-						elMap, ok := el.(map[string]interface{})
-						if ok {
-							elType, found := elMap["@type"]
-							if found {
-								typeStr, ok := elType.(string)
-								if ok && jsonLdArticleTypes.MatchString(typeStr) {
-									parsed = elMap
-									break
-								}
-							}
-						}
-					}
-				}
-			}
-
-			if parsed == nil || parsed["@type"] == nil || !jsonLdArticleTypes.MatchString(parsed["@type"].(string)) {
-				continue
-			}
-
-			meta = &metadata{}
-			name, nameFound := parsed["name"]
-			headline, headlineFound := parsed["headline"]
-
-			if nameFound && headlineFound && reflect.ValueOf(name).Kind() == reflect.String && reflect.ValueOf(headline).Kind() == reflect.String && name.(string) != headline.(string) {
-				// we have both name and headline element in the JSON-LD. They should both be the same but some websites like aktualne.cz
-				// put their own name into "name" and the article title to "headline" which confuses Readability. So we try to check if either
-				// "name" or "headline" closely matches the html title, and if so, use that one. If not, then we use "name" by default.
-
-				var title = r.getArticleTitle()
-				var nameMatches = r.textSimilarity(name.(string), title) > 0.75
-				var headlineMatchs = r.textSimilarity(headline.(string), title) > 0.75
-
-				if headlineMatchs && !nameMatches {
-					meta.title = headline.(string)
-				} else {
-					meta.title = name.(string)
-				}
-			} else if _, ok := name.(string); ok {
-				meta.title = strings.TrimSpace(name.(string))
-			} else if _, ok := headline.(string); ok {
-				meta.title = strings.TrimSpace(headline.(string))
-			}
-
-			author, authorFound := parsed["author"]
-			if authorFound {
-				if _, isObj := author.(map[string]interface{}); isObj {
-					auth := author.(map[string]interface{})
-					authorName, authorNameFound := auth["name"]
-					if authorNameFound {
-						if _, isStr := authorName.(string); isStr {
-							meta.byline = strings.TrimSpace(auth["name"].(string))
-						}
-					}
-				} else if _, isArray := author.([]interface{}); isArray {
-					authors := author.([]interface{})
-					if len(authors) != 0 {
-						firstAuthor, isObj := authors[0].(map[string]interface{})
-						if isObj {
-							authorName, authorNameFound := firstAuthor["name"]
-							if authorNameFound {
-								if _, isStr := authorName.(string); isStr {
-									var authorNames []string
-									for _, a := range authors {
-										n := a.(map[string]interface{})["name"].(string)
-										authorNames = append(authorNames, strings.TrimSpace(n))
-									}
-									meta.byline = strings.Join(authorNames, ", ")
-								}
-							}
-						}
-					}
-				}
-			}
-
-			descr, ok := parsed["description"]
-			if ok {
-				if _, ok := descr.(string); ok {
-					meta.excerpt = strings.TrimSpace(descr.(string))
-				}
-			}
-			publisher, ok := parsed["publisher"]
-			if ok {
-				if _, isObj := publisher.(map[string]interface{}); isObj {
-					publisherName, publisherNameFound := publisher.(map[string]interface{})["name"]
-					if publisherNameFound {
-						if _, isStr := publisherName.(string); isStr {
-							meta.siteName = strings.TrimSpace(publisherName.(string))
-						}
-					}
-				}
-			}
-			datePublished, ok := parsed["datePublished"]
-			if ok {
-				if _, ok := datePublished.(string); ok {
-					meta.datePublished = strings.TrimSpace(datePublished.(string))
-				}
-			}
-			continue
-		}
-	}
-	return meta
+	dateModified  string
+	modifiedTime  string
+	image         *Image
+	url           string
+	favicon       string
+	alternates    map[string]string
+	tags          []string
+	section       string
+	lang          string
+	jsonld        map[string]interface{}
+	citation      *Citation
+	authors       []Author
+	// authors declared by less reliable sources than the byline
+	fallbackAuthors []Author
 }
 
 // Attempts to get excerpt and byline metadata for the article.
 // Accepts as param 'jsonld' an object containing any metadata that
 // could be extracted from a JSON-LD object.
 // Returns an object with optional "excerpt" and "byline" properties.
-func (r *Readability) getArticleMetadata(jsonld *metadata) *metadata {
+func (r *Readability) getArticleMetadata(jsonld, microdata *metadata) *metadata {
 
 	var meta, values = &metadata{}, make(map[string]string, 0)
 	var metaElements = r.doc.getElementsByTagName("meta")
 
+	var tags []string
+	var authors = make(map[string][]string)
+
+	var setValue = func(name, value string) {
+		switch name {
+		case "citation_author", "dc:creator", "dcterm:creator", "author", "article:author":
+			// Scholarly articles have a meta tag for each of their authors.
+			authors[name] = append(authors[name], value)
+		case "article:tag":
+			tags = append(tags, value)
+			return
+		case "keywords", "news_keywords":
+			tags = append(tags, splitList(value)...)
+			return
+		}
+		// Pages can declare many images: the first one is usually the most relevant.
+		if _, found := values[name]; found && strings.Contains(name, "image") {
+			return
+		}
+		values[name] = value
+	}
+
 	for _, element := range metaElements {
 		var elementName = element.GetAttribute("name")
 		var elementProperty = element.GetAttribute("property")
@@ -1459,6 +1582,11 @@ func (r *Readability) getArticleMetadata(jsonld *metadata) *metadata {
 			continue
 		}
 
+		if strings.EqualFold(strings.TrimSpace(element.GetAttribute("http-equiv")), "content-language") {
+			setValue("content-language", strings.TrimSpace(content))
+			continue
+		}
+
 		var matches []string
 		var name string
 
@@ -1469,7 +1597,7 @@ func (r *Readability) getArticleMetadata(jsonld *metadata) *metadata {
 				// so we can match below.
 				name = singleWhitespace.ReplaceAllString(strings.ToLower(matches[0]), "")
 				// multiple authors
-				values[name] = strings.TrimSpace(content)
+				setValue(name, strings.TrimSpace(content))
 			}
 		}
 
@@ -1480,18 +1608,57 @@ func (r *Readability) getArticleMetadata(jsonld *metadata) *metadata {
 				// to colons so we can match below.
 				name = singleWhitespace.ReplaceAllString(strings.ToLower(name), "")
 				name = singleDot.ReplaceAllString(name, ":")
-				values[name] = strings.TrimSpace(content)
+				setValue(name, strings.TrimSpace(content))
 
 			}
 		}
 	}
 
+	var favicons []*Node
+	for _, element := range r.doc.getElementsByTagName("link") {
+		var rels = strings.Fields(strings.ToLower(element.GetAttribute("rel")))
+		var href = strings.TrimSpace(element.GetAttribute("href"))
+		if href == "" {
+			continue
+		}
+		switch {
+		case slices.Contains(rels, "image_src"):
+			setValue("image_src", href)
+		case slices.Contains(rels, "canonical"):
+			if _, found := values["canonical"]; !found {
+				setValue("canonical", href)
+			}
+		case slices.Contains(rels, "alternate") && element.GetAttribute("hreflang") != "":
+			if meta.alternates == nil {
+				meta.alternates = make(map[string]string)
+			}
+			meta.alternates[strings.TrimSpace(element.GetAttribute("hreflang"))] = r.toAbsoluteURI(href)
+		case slices.ContainsFunc(rels, isIconRel):
+			favicons = append(favicons, element)
+		}
+	}
+	if favicon := largestIcon(favicons); favicon != nil {
+		meta.favicon = r.toAbsoluteURI(favicon.GetAttribute("href"))
+	}
+
 	if jsonld == nil {
 		jsonld = &metadata{}
 	}
+	if microdata == nil {
+		microdata = &metadata{}
+	}
+
+	// get citation, whose title and authors are preferred on scholarly pages
+	meta.citation = r.getCitation(values, authors, jsonld.jsonld)
+	var citation = meta.citation
+	if citation == nil {
+		citation = &Citation{}
+	}
 
 	// get title
-	meta.title = anyOf(jsonld.title,
+	meta.title = anyOf(citation.Title,
+		jsonld.title,
+		microdata.title,
 		values["dc:title"],
 		values["dcterm:title"],
 		values["og:title"],
@@ -1505,13 +1672,36 @@ func (r *Readability) getArticleMetadata(jsonld *metadata) *metadata {
 	}
 
 	// get author
-	meta.byline = anyOf(jsonld.byline,
+	var citationNames []string
+	for _, name := range citation.Authors {
+		citationNames = append(citationNames, citationAuthorName(name))
+	}
+	meta.byline = anyOf(strings.Join(citationNames, ", "),
+		jsonld.byline,
+		microdata.byline,
 		values["dc:creator"],
 		values["dcterm:creator"],
 		values["author"])
 
+	// get authors
+	var metaAuthors = anyList(authors["dc:creator"],
+		authors["dcterm:creator"],
+		authors["author"])
+	var citationAuthors []Author
+	for _, name := range citationNames {
+		citationAuthors = append(citationAuthors, Author{Name: r.unescapeHtmlEntities(name)})
+	}
+	meta.authors = mergeAuthors(citationAuthors,
+		jsonld.authors,
+		microdata.authors,
+		r.authorsFromValues(metaAuthors),
+		r.authorsFromValues(authors["article:author"]))
+	meta.fallbackAuthors = mergeAuthors(r.authorsFromLinks(r.doc),
+		twitterAuthor(values["twitter:creator"]))
+
 	// get description
 	meta.excerpt = anyOf(jsonld.excerpt,
+		microdata.excerpt,
 		values["dc:description"],
 		values["dcterm:description"],
 		values["og:description"],
@@ -1522,11 +1712,52 @@ func (r *Readability) getArticleMetadata(jsonld *metadata) *metadata {
 
 	// get site name
 	meta.siteName = anyOf(jsonld.siteName,
+		microdata.siteName,
 		values["og:site_name"])
 
 	// get article published time
 	meta.publishedTime = anyOf(jsonld.datePublished,
-		values["article:published_time"])
+		microdata.datePublished,
+		values["article:published_time"],
+		values["citation_publication_date"],
+		values["citation_date"])
+
+	// get article modified time
+	meta.modifiedTime = anyOf(jsonld.dateModified,
+		microdata.dateModified,
+		values["article:modified_time"],
+		values["og:updated_time"])
+
+	// get tags and section
+	meta.tags = slices.Concat(tags, jsonld.tags, microdata.tags)
+	meta.section = anyOf(jsonld.section,
+		microdata.section,
+		values["article:section"])
+	meta.jsonld = jsonld.jsonld
+
+	// get language, used when the document element has no lang attribute
+	meta.lang = anyOf(normalizeLanguage(values["content-language"]),
+		normalizeLanguage(values["og:locale"]),
+		normalizeLanguage(jsonld.lang),
+		normalizeLanguage(microdata.lang))
+
+	// get canonical URL
+	meta.url = anyOf(values["canonical"],
+		values["og:url"],
+		jsonld.url,
+		microdata.url)
+	if meta.url != "" {
+		meta.url = r.toAbsoluteURI(r.unescapeHtmlEntities(meta.url))
+	}
+
+	// get lead image
+	meta.image = jsonld.image
+	if meta.image == nil {
+		meta.image = microdata.image
+	}
+	if meta.image == nil {
+		meta.image = imageFromMetaValues(values)
+	}
 
 	// in many sites the meta value is escaped with HTML entities,
 	// so here we need to unescape it
@@ -1535,6 +1766,29 @@ func (r *Readability) getArticleMetadata(jsonld *metadata) *metadata {
 	meta.excerpt = r.unescapeHtmlEntities(meta.excerpt)
 	meta.siteName = r.unescapeHtmlEntities(meta.siteName)
 	meta.publishedTime = r.unescapeHtmlEntities(meta.publishedTime)
+	meta.modifiedTime = r.unescapeHtmlEntities(meta.modifiedTime)
+	meta.section = r.unescapeHtmlEntities(meta.section)
+	for i, tag := range meta.tags {
+		meta.tags[i] = strings.TrimSpace(r.unescapeHtmlEntities(tag))
+	}
+	// Tags are compared once unescaped, e.g. "A&amp;B" and "A&B".
+	meta.tags = uniqueStrings(slices.DeleteFunc(meta.tags, func(tag string) bool { return tag == "" }))
+	if meta.image != nil {
+		meta.image.URL = r.toAbsoluteURI(r.unescapeHtmlEntities(meta.image.URL))
+		meta.image.Alt = r.unescapeHtmlEntities(meta.image.Alt)
+	}
+	for i, author := range meta.authors {
+		meta.authors[i].Name = r.unescapeHtmlEntities(author.Name)
+		meta.authors[i].URL = r.toAbsoluteURI(r.unescapeHtmlEntities(author.URL))
+	}
+	if meta.citation != nil {
+		meta.citation.Title = r.unescapeHtmlEntities(meta.citation.Title)
+		meta.citation.JournalTitle = r.unescapeHtmlEntities(meta.citation.JournalTitle)
+		meta.citation.Publisher = r.unescapeHtmlEntities(meta.citation.Publisher)
+		for i, author := range meta.citation.Authors {
+			meta.citation.Authors[i] = r.unescapeHtmlEntities(author)
+		}
+	}
 
 	return meta
 }
@@ -1573,7 +1827,7 @@ func (r *Readability) unwrapNoscriptImages(doc *Node) {
 
 		if !containsImg {
 			if _, err := img.ParentNode.RemoveChild(img); err != nil {
-				slog.Error("cannot remove child", slog.String("err", err.Error()))
+				r.logger.Error("cannot remove child", slog.String("err", err.Error()))
 			}
 		}
 	}
@@ -1818,7 +2072,7 @@ func (r *Readability) getRowAndColumnCount(table *Node) (int, int) {
 		if rowspan != "" {
 			num, err := strconv.Atoi(rowspan)
 			if err != nil {
-				slog.Error(err.Error())
+				r.logger.Error(err.Error())
 			}
 			rs = num
 		}
@@ -1837,7 +2091,7 @@ func (r *Readability) getRowAndColumnCount(table *Node) (int, int) {
 			if colspan != "" {
 				num, err := strconv.Atoi(colspan)
 				if err != nil {
-					slog.Error(err.Error())
+					r.logger.Error(err.Error())
 				}
 				cs = num
 			}
@@ -1890,7 +2144,7 @@ func (r *Readability) markDataTables(root *Node) {
 		}
 
 		if slices.ContainsFunc(dataTableDescendants, descendantExists) {
-			slog.Debug("Data table because found data-y descendant")
+			r.logger.Debug("Data table because found data-y descendant")
 			table.ReadabilityDataTable = &readabilityDataTable{value: true}
 			continue
 		}
@@ -2043,7 +2297,7 @@ func (r *Readability) cleanConditionally(e *Node, tag string) {
 
 		var weight = r.getClassWeight(n)
 
-		slog.Debug("Cleaning Conditionally", "node", n)
+		r.logger.Debug("Cleaning Conditionally", "node", n)
 
 		var contentScore = 0.0
 
@@ -2131,7 +2385,7 @@ func (r *Readability) cleanHeaders(n *Node) {
 	r.removeNodes(headingNodes, func(nn *Node) bool {
 		var shouldRemove = r.getClassWeight(nn) < 0
 		if shouldRemove {
-			slog.Debug("Removing header with low class weight", "node", nn)
+			r.logger.Debug("Removing header with low class weight", "node", nn)
 		}
 		return shouldRemove
 	})
@@ -2144,7 +2398,7 @@ func (r *Readability) headerDuplicatesTitle(n *Node) bool {
 		return false
 	}
 	var heading = r.getInnerText(n, false)
-	slog.Debug("Evaluating similarity of header", "heading", heading, "articleTitle", r.articleTitle)
+	r.logger.Debug("Evaluating similarity of header", "heading", heading, "articleTitle", r.articleTitle)
 	return r.textSimilarity(r.articleTitle, heading) > 0.75
 }
 
@@ -2172,11 +2426,20 @@ func isProbablyVisible(n *Node) bool {
 //  4. Replace the current DOM tree with the new one.
 //  5. Read peacefully.
 func (r *Readability) Parse() (*Result, error) {
+	return r.ParseContext(context.Background())
+}
+
+// ParseContext is like Parse but stops as soon as the given context is done.
+// The context is checked between the stages of the workflow and inside their
+// longest loops: when it is done, a *StageError wrapping ctx.Err() is
+// returned along with the name of the interrupted stage.
+// A timeout can also be set for each stage with the StageTimeout option.
+func (r *Readability) ParseContext(ctx context.Context) (*Result, error) {
 	// Avoid parsing too large documents, as per configuration option
 	if r.options.maxElemsToParse > 0 {
 		var numTags = len(r.doc.getElementsByTagName("*"))
 		if numTags > r.options.maxElemsToParse {
-			return nil, fmt.Errorf("aborting parsing document: elements_found=%d", numTags)
+			return nil, &TooManyElementsError{Found: numTags, Limit: r.options.maxElemsToParse}
 		}
 	}
 
@@ -2192,19 +2455,60 @@ func (r *Readability) Parse() (*Result, error) {
 	// Remove script tags from the document.
 	r.removeScripts(r.doc)
 
-	r.prepDocument()
+	if err := r.runStage(ctx, StagePrepDocument, r.prepDocument); err != nil {
+		return nil, err
+	}
+
+	// Extract microdata and RDFa metadata
+	var microdata *metadata
+	if !r.options.disableMicrodata {
+		microdata = r.getMicrodata(r.doc)
+	}
 
-	var metadata = r.getArticleMetadata(jsonLd)
+	var metadata = r.getArticleMetadata(jsonLd, microdata)
 	r.articleTitle = metadata.title
 
-	var articleContent = r.grabArticle(nil)
+	// Find the next page before the pagination links are removed with the clutter.
+	var nextPage string
+	var pages = make(map[string]bool)
+	if r.options.fetcher != nil {
+		nextPage = r.findNextPageLink(pages)
+	}
+
+	var articleContent *Node
+	err := r.runStage(ctx, StageGrabArticle, func(ctx context.Context) error {
+		var err error
+		articleContent, err = r.grabArticle(ctx, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 	if articleContent == nil {
-		return nil, fmt.Errorf("cannot grab article")
+		var textLengths []int
+		for _, attempt := range r.attempts {
+			textLengths = append(textLengths, attempt.textLength)
+		}
+		return nil, &ExtractionFailedError{Attempts: textLengths}
 	}
 
-	slog.Debug("grabbed", "articleContent.innerHTML", articleContent.GetInnerHTML())
+	r.logger.Debug("grabbed", "articleContent.innerHTML", articleContent.GetInnerHTML())
 
-	r.postProcessContent(articleContent)
+	if nextPage != "" {
+		err = r.runStage(ctx, StageFetchPages, func(ctx context.Context) error {
+			return r.appendNextPages(ctx, articleContent, nextPage, pages)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = r.runStage(ctx, StagePostProcessContent, func(ctx context.Context) error {
+		return r.postProcessContent(ctx, articleContent)
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	// If we haven't found an excerpt in the article's metadata, use the article's
 	// first paragraph as the excerpt. This is used for displaying a preview of
@@ -2216,25 +2520,78 @@ func (r *Readability) Parse() (*Result, error) {
 		}
 	}
 
+	publishedTime, ok := parseDate(metadata.publishedTime)
+	if !ok {
+		publishedTime, ok = r.dateFromTimeElements(articleContent)
+	}
+	if !ok {
+		publishedTime, _ = dateFromURL(r.doc.DocumentURI)
+	}
+	modifiedTime, _ := parseDate(metadata.modifiedTime)
+
+	var image = metadata.image
+	if image == nil {
+		image = r.getLeadImageFromContent(articleContent)
+	}
+
 	htmlContent := r.options.serializer(articleContent)
 
 	var textContent string
 	if r.options.html2text != nil {
 		textContent = r.options.html2text(htmlContent)
+	} else if r.options.textSerializer != nil {
+		textContent = r.options.textSerializer(articleContent)
 	} else {
 		textContent = articleContent.GetTextContent()
 	}
 
+	var lang, langConfidence = r.articleLang, 1.0
+	if lang == "" {
+		lang = metadata.lang
+	}
+	if lang == "" {
+		langConfidence = 0
+		if !r.options.disableLanguageDetection {
+			lang, langConfidence = DetectLanguage(textContent)
+		}
+	}
+
+	var words, chars = countWords(readableText(articleContent))
+	var wordCount = words
+	for _, count := range chars {
+		wordCount += count
+	}
+
 	return &Result{
-		Title:         r.articleTitle,
-		Byline:        anyOf(metadata.byline, r.articleByline),
-		Dir:           r.articleDir,
-		Lang:          r.articleLang,
-		HTMLContent:   htmlContent,
-		TextContent:   textContent,
-		Length:        len([]rune(textContent)),
-		Excerpt:       metadata.excerpt,
-		SiteName:      anyOf(metadata.siteName, r.articleSiteName),
-		PublishedTime: metadata.publishedTime,
+		Title:          r.articleTitle,
+		Byline:         anyOf(metadata.byline, r.articleByline),
+		Authors:        mergeAuthors(metadata.authors, r.authorsFromByline(r.articleByline), metadata.fallbackAuthors),
+		Dir:            r.articleDir,
+		Lang:           lang,
+		LangConfidence: langConfidence,
+		HTMLContent:    htmlContent,
+		TextContent:    textContent,
+		Content:        articleContent,
+		Length:         len([]rune(textContent)),
+		WordCount:      wordCount,
+		ReadingTime:    r.readingTime(words, chars, lang),
+		Outline:        r.getOutline(articleContent),
+		Links:          r.links,
+		Media:          r.getMedia(articleContent),
+		Excerpt:        metadata.excerpt,
+		SiteName:       anyOf(metadata.siteName, r.articleSiteName),
+		PublishedTime:  metadata.publishedTime,
+		Published:      publishedTime,
+		ModifiedTime:   metadata.modifiedTime,
+		Modified:       modifiedTime,
+		Encoding:       r.encoding,
+		Image:          image,
+		CanonicalURL:   metadata.url,
+		Favicon:        metadata.favicon,
+		Alternates:     metadata.alternates,
+		Tags:           metadata.tags,
+		Section:        metadata.section,
+		JSONLD:         metadata.jsonld,
+		Citation:       metadata.citation,
 	}, nil
 }
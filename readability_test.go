@@ -1,18 +1,26 @@
 package readability
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"io/fs"
+	"log/slog"
+	"net/url"
 	"os"
 	"path"
 	"regexp"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/yosssi/gohtml"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
 )
 
 type testPage struct {
@@ -97,6 +105,8 @@ func TestParse(t *testing.T) {
 
 			reader, err := New(string(testPage.source), uri,
 				ClassesToPreserve("caption"),
+				// Readability.js, which produced the expected results, ignores microdata and RDFa.
+				DisableMicrodata(true),
 			)
 			if err != nil {
 				t.Error(err)
@@ -185,11 +195,19 @@ func TestParse(t *testing.T) {
 			})
 
 			t.Run("should extract expected language", func(t *testing.T) {
-				assert.Equal(t, testPage.expectedMetadata.Lang, result.Lang)
+				// Readability.js only reads the lang attribute of the document element,
+				// while the language can also be declared by the metadata or guessed.
+				assert.Equal(t, testPage.expectedMetadata.Lang, reader.articleLang)
+				if testPage.expectedMetadata.Lang != "" {
+					assert.Equal(t, testPage.expectedMetadata.Lang, result.Lang)
+				}
 			})
 
 			t.Run("should extract expected published time", func(t *testing.T) {
 				assert.Equal(t, testPage.expectedMetadata.PublishedTime, result.PublishedTime)
+				if testPage.expectedMetadata.PublishedTime != "" {
+					assert.False(t, result.Published.IsZero(), "cannot parse %q", result.PublishedTime)
+				}
 			})
 
 			t.Run("should infer if the article is readerable", func(t *testing.T) {
@@ -281,3 +299,430 @@ func traverseDOM(callback func(*Node, *Node) bool, expectedDOM, actualDOM *Node)
 func htmlTransform(str string) string {
 	return regexp.MustCompile(`\s+`).ReplaceAllString(str, " ")
 }
+
+func TestNewFromReader(t *testing.T) {
+
+	var article = strings.Repeat("<p>Съешь же ещё этих мягких французских булок, да выпей чаю. "+
+		"Широкая электрификация южных губерний даст мощный толчок подъёму сельского хозяйства.</p>", 5)
+
+	var encode = func(t *testing.T, enc encoding.Encoding, s string) []byte {
+		b, err := enc.NewEncoder().Bytes([]byte(s))
+		assert.NoError(t, err)
+		return b
+	}
+
+	var pageURL, _ = url.Parse("http://fakehost/test/page.html")
+
+	testCases := []struct {
+		name         string
+		source       []byte
+		contentType  string
+		wantEncoding string
+	}{
+		{
+			name:         "should detect the encoding from the BOM",
+			source:       append([]byte("\xef\xbb\xbf"), []byte("<html><body>"+article+"</body></html>")...),
+			wantEncoding: "utf-8",
+		},
+		{
+			name:         "should detect the encoding from the Content-Type header",
+			source:       encode(t, charmap.Windows1251, "<html><body>"+article+"</body></html>"),
+			contentType:  "text/html; charset=windows-1251",
+			wantEncoding: "windows-1251",
+		},
+		{
+			name:         "should detect the encoding from a meta charset",
+			source:       encode(t, charmap.Windows1251, `<html><head><meta charset="windows-1251"></head><body>`+article+"</body></html>"),
+			contentType:  "text/html",
+			wantEncoding: "windows-1251",
+		},
+		{
+			name:         "should detect the encoding from a meta http-equiv",
+			source:       encode(t, charmap.KOI8R, `<html><head><meta http-equiv="Content-Type" content="text/html; charset=koi8-r"></head><body>`+article+"</body></html>"),
+			wantEncoding: "koi8-r",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reader, err := NewFromReader(bytes.NewReader(tc.source), pageURL, tc.contentType)
+			assert.NoError(t, err)
+			result, err := reader.Parse()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantEncoding, result.Encoding)
+			assert.Contains(t, result.TextContent, "Съешь же ещё этих мягких французских булок")
+		})
+	}
+
+	t.Run("should decode undeclared UTF-8 documents", func(t *testing.T) {
+		// The first 1024 bytes are ASCII.
+		var source = []byte("<html><head><title>Test</title><!-- " + strings.Repeat("padding ", 150) + "--></head><body>" + article + "</body></html>")
+		reader, err := NewFromReader(bytes.NewReader(source), pageURL, "text/html")
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		assert.Equal(t, "utf-8", result.Encoding)
+		assert.Contains(t, result.TextContent, "Съешь же ещё этих мягких французских булок")
+	})
+
+	t.Run("should transcode Shift_JIS documents", func(t *testing.T) {
+		var novel = strings.Repeat("<p>吾輩は猫である。名前はまだ無い。どこで生れたかとんと見当がつかぬ。何でも薄暗いじめじめした所でニャーニャー泣いていた事だけは記憶している。</p>", 10)
+		var source = encode(t, japanese.ShiftJIS, `<html><head><meta charset="Shift_JIS"></head><body>`+novel+"</body></html>")
+		reader, err := NewFromReader(bytes.NewReader(source), pageURL, "")
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		assert.Equal(t, "shift_jis", result.Encoding)
+		assert.Contains(t, result.TextContent, "吾輩は猫である。")
+	})
+}
+
+func TestParseContext(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	source, err := os.ReadFile("testdata/test-pages/001/source.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("should stop before the first stage if the context is already done", func(t *testing.T) {
+		reader, err := New(string(source), uri)
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		result, err := reader.ParseContext(ctx)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.True(t, strings.HasPrefix(err.Error(), string(StagePrepDocument)+":"))
+	})
+
+	t.Run("should stop a stage exceeding its timeout", func(t *testing.T) {
+		reader, err := New(string(source), uri, StageTimeout(StageGrabArticle, time.Nanosecond))
+		assert.NoError(t, err)
+
+		result, err := reader.ParseContext(context.Background())
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.True(t, strings.HasPrefix(err.Error(), string(StageGrabArticle)+":"))
+	})
+
+	t.Run("should parse the document if the context is never done", func(t *testing.T) {
+		reader, err := New(string(source), uri, StageTimeout(StageGrabArticle, time.Minute))
+		assert.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		result, err := reader.ParseContext(ctx)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result.TextContent)
+	})
+}
+
+func TestErrors(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	t.Run("should return ErrEmptyDocument for an empty source", func(t *testing.T) {
+		_, err := New("", uri)
+		assert.ErrorIs(t, err, ErrEmptyDocument)
+	})
+
+	t.Run("should return ErrNoBody for a document without body", func(t *testing.T) {
+		_, err := New("<p>no body here</p>", uri)
+		assert.ErrorIs(t, err, ErrNoBody)
+	})
+
+	t.Run("should return a TooManyElementsError for a document too large", func(t *testing.T) {
+		reader, err := New("<html><body><p>one</p><p>two</p></body></html>", uri, MaxElemsToParse(2))
+		assert.NoError(t, err)
+		_, err = reader.Parse()
+		var tooMany *TooManyElementsError
+		if assert.ErrorAs(t, err, &tooMany) {
+			assert.Equal(t, 4, tooMany.Found)
+			assert.Equal(t, 2, tooMany.Limit)
+		}
+	})
+
+	t.Run("should return an ExtractionFailedError for a document without content", func(t *testing.T) {
+		reader, err := New("<html><body><div></div></body></html>", uri)
+		assert.NoError(t, err)
+		_, err = reader.Parse()
+		assert.ErrorIs(t, err, ErrNotReaderable)
+		var failed *ExtractionFailedError
+		if assert.ErrorAs(t, err, &failed) {
+			assert.Equal(t, []int{0, 0, 0, 0}, failed.Attempts)
+		}
+	})
+
+	t.Run("should return a StageError when the context is done", func(t *testing.T) {
+		reader, err := New("<html><body><p>text</p></body></html>", uri)
+		assert.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = reader.ParseContext(ctx)
+		var stageErr *StageError
+		if assert.ErrorAs(t, err, &stageErr) {
+			assert.Equal(t, StagePrepDocument, stageErr.Stage)
+		}
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestLogger(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	source, err := os.ReadFile("testdata/test-pages/001/source.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("should log through the given logger with structured attributes", func(t *testing.T) {
+		var buf bytes.Buffer
+		var logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+		reader, err := New(string(source), uri, Logger(logger))
+		assert.NoError(t, err)
+		_, err = reader.Parse()
+		assert.NoError(t, err)
+
+		var attempts int
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var record map[string]interface{}
+			assert.NoError(t, json.Unmarshal([]byte(line), &record))
+			assert.Equal(t, uri, record["uri"])
+			if record["msg"] == "Starting grabArticle loop" {
+				attempts++
+				assert.Equal(t, float64(attempts), record["attempt"])
+			}
+		}
+		assert.NotZero(t, attempts)
+	})
+
+	t.Run("should not log through the default logger", func(t *testing.T) {
+		var buf bytes.Buffer
+		var defaultLogger = slog.Default()
+		slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+		defer slog.SetDefault(defaultLogger)
+
+		reader, err := New(string(source), uri)
+		assert.NoError(t, err)
+		_, err = reader.Parse()
+		assert.NoError(t, err)
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestLinkMetadata(t *testing.T) {
+
+	var article = "<body><article>" + strings.Repeat("<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>", 5) + "</article></body>"
+
+	var parse = func(t *testing.T, uri, head string) *Result {
+		reader, err := New("<html><head>"+head+"</head>"+article+"</html>", uri)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should extract the canonical URL", func(t *testing.T) {
+		testCases := []struct {
+			head string
+			want string
+		}{
+			{
+				head: `<link rel="canonical" href="/news/120"><meta property="og:url" content="http://fakehost/og">`,
+				want: "http://fakehost/news/120",
+			},
+			{
+				head: `<meta property="og:url" content="http://fakehost/og">`,
+				want: "http://fakehost/og",
+			},
+			{
+				head: `<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","mainEntityOfPage":{"@type":"WebPage","@id":"https://fakehost/ld"}}</script>`,
+				want: "https://fakehost/ld",
+			},
+			{
+				head: `<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","url":"/ld-url"}</script>`,
+				want: "http://fakehost/ld-url",
+			},
+			{
+				head: `<base href="http://other/base/"><link rel="canonical" href="page">`,
+				want: "http://other/base/page",
+			},
+		}
+		for _, tc := range testCases {
+			assert.Equal(t, tc.want, parse(t, "http://fakehost/test/page.html", tc.head).CanonicalURL)
+		}
+	})
+
+	t.Run("should extract the largest favicon", func(t *testing.T) {
+		var result = parse(t, "http://fakehost/test/page.html",
+			`<link rel="shortcut icon" href="/favicon.ico">`+
+				`<link rel="apple-touch-icon" sizes="180x180" href="/apple-touch-icon.png">`+
+				`<link rel="icon" sizes="32x32" href="/favicon-32.png">`)
+		assert.Equal(t, "http://fakehost/apple-touch-icon.png", result.Favicon)
+
+		result = parse(t, "http://fakehost/test/page.html", `<link rel="icon" href="favicon.ico">`)
+		assert.Equal(t, "http://fakehost/test/favicon.ico", result.Favicon)
+	})
+
+	t.Run("should extract the alternate languages", func(t *testing.T) {
+		var result = parse(t, "http://fakehost/en/page.html",
+			`<link rel="alternate" hreflang="de" href="/de/page.html">`+
+				`<link rel="alternate" hreflang="x-default" href="http://fakehost/page.html">`+
+				`<link rel="alternate" type="application/rss+xml" href="/rss">`)
+		assert.Equal(t, map[string]string{
+			"de":        "http://fakehost/de/page.html",
+			"x-default": "http://fakehost/page.html",
+		}, result.Alternates)
+	})
+}
+
+func TestTopicalMetadata(t *testing.T) {
+
+	var article = "<body><article>" + strings.Repeat("<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>", 5) + "</article></body>"
+
+	var parse = func(t *testing.T, head string) *Result {
+		reader, err := New("<html><head>"+head+"</head>"+article+"</html>", "http://fakehost/test/page.html")
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should extract tags from meta tags and JSON-LD without duplicates", func(t *testing.T) {
+		var result = parse(t, `<meta property="article:tag" content="Go">`+
+			`<meta property="article:tag" content="Readability">`+
+			`<meta name="keywords" content="go, parsing,  html ,">`+
+			`<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","keywords":["HTML","Reader mode"]}</script>`)
+		assert.Equal(t, []string{"Go", "Readability", "parsing", "html", "Reader mode"}, result.Tags)
+	})
+
+	t.Run("should remove duplicates once the tags are unescaped and trimmed", func(t *testing.T) {
+		var result = parse(t, `<meta property="article:tag" content="R&amp;amp;D">`+
+			`<meta property="article:tag" content=" R&amp;D ">`+
+			`<meta property="article:tag" content="r&amp;d">`+
+			`<meta property="article:tag" content=" ">`+
+			`<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","keywords":["R&amp;D","Science"]}</script>`)
+		assert.Equal(t, []string{"R&D", "Science"}, result.Tags)
+	})
+
+	t.Run("should split JSON-LD keywords given as a string", func(t *testing.T) {
+		var result = parse(t, `<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","keywords":"one, two,three"}</script>`)
+		assert.Equal(t, []string{"one", "two", "three"}, result.Tags)
+	})
+
+	t.Run("should extract the section", func(t *testing.T) {
+		var result = parse(t, `<meta property="article:section" content="Technology">`)
+		assert.Equal(t, "Technology", result.Section)
+
+		result = parse(t, `<meta property="article:section" content="Technology">`+
+			`<script type="application/ld+json">{"@context":"https://schema.org","@type":"Article","articleSection":["Science","Space"]}</script>`)
+		assert.Equal(t, "Science", result.Section)
+	})
+}
+
+func TestJSONLD(t *testing.T) {
+
+	var article = "<body><article>" + strings.Repeat("<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.</p>", 5) + "</article></body>"
+
+	var parse = func(t *testing.T, jsonLd string) *Result {
+		reader, err := New(`<html><head><script type="application/ld+json">`+jsonLd+`</script></head>`+article+"</html>", "http://fakehost/test/page.html")
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	testCases := []struct {
+		name   string
+		jsonLd string
+		title  string
+		byline string
+	}{
+		{
+			name:   "plain object",
+			jsonLd: `{"@context":"https://schema.org","@type":"NewsArticle","headline":"Plain","author":{"@type":"Person","name":"Jane Doe"}}`,
+			title:  "Plain",
+			byline: "Jane Doe",
+		},
+		{
+			name:   "top-level array",
+			jsonLd: `[{"@context":"https://schema.org","@type":"BreadcrumbList"},{"@context":"https://schema.org","@type":"Article","headline":"In array"}]`,
+			title:  "In array",
+		},
+		{
+			name:   "@context object",
+			jsonLd: `{"@context":{"@vocab":"http://schema.org/","@language":"en"},"@type":"Article","headline":"Context object"}`,
+			title:  "Context object",
+		},
+		{
+			name:   "@context array",
+			jsonLd: `{"@context":["https://schema.org",{"@language":"en"}],"@type":"Article","headline":"Context array"}`,
+			title:  "Context array",
+		},
+		{
+			name:   "@type array",
+			jsonLd: `{"@context":"https://schema.org","@type":["NewsArticle","Article"],"headline":"Type array"}`,
+			title:  "Type array",
+		},
+		{
+			name:   "absolute @type IRI",
+			jsonLd: `{"@context":"https://schema.org","@type":"http://schema.org/BlogPosting","headline":"Type IRI"}`,
+			title:  "Type IRI",
+		},
+		{
+			name: "@graph with references",
+			jsonLd: `{"@context":"https://schema.org","@graph":[` +
+				`{"@type":"WebPage","@id":"http://fakehost/test/page.html","mainEntity":{"@id":"#article"}},` +
+				`{"@type":"Article","@id":"#article","headline":"In graph","author":[{"@id":"#jane"},"Nobody",{"@id":"#unknown"}]},` +
+				`{"@type":"Person","@id":"#jane","name":"Jane Doe"}]}`,
+			title:  "In graph",
+			byline: "Jane Doe",
+		},
+		{
+			name:   "mainEntity",
+			jsonLd: `{"@context":"https://schema.org","@type":"WebPage","mainEntity":{"@type":"Article","headline":"Main entity"}}`,
+			title:  "Main entity",
+		},
+		{
+			name:   "reference cycle",
+			jsonLd: `{"@context":"https://schema.org","@graph":[{"@type":"Article","@id":"#a","headline":"Cycle","isPartOf":{"@id":"#b"}},{"@type":"WebPage","@id":"#b","hasPart":{"@id":"#a"}}]}`,
+			title:  "Cycle",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run("should find the article in "+tc.name, func(t *testing.T) {
+			var result = parse(t, tc.jsonLd)
+			assert.Equal(t, tc.title, result.Title)
+			assert.Equal(t, tc.byline, result.Byline)
+			assert.NotNil(t, result.JSONLD)
+			assert.Equal(t, tc.title, result.JSONLD["headline"])
+		})
+	}
+
+	t.Run("should ignore objects which are not Schema.org articles", func(t *testing.T) {
+		for _, jsonLd := range []string{
+			`{"@context":"https://example.com","@type":"Article","headline":"Other vocabulary"}`,
+			`{"@context":{"@language":"en"},"@type":"Article","headline":"No vocabulary"}`,
+			`{"@context":"https://schema.org","@type":["WebPage",42],"headline":"Not an article"}`,
+			`{"@context":42,"@type":{},"headline":"Malformed"}`,
+			`"just a string"`,
+		} {
+			var result = parse(t, jsonLd)
+			assert.Nil(t, result.JSONLD, jsonLd)
+		}
+	})
+
+	t.Run("should expose fields which are not mapped", func(t *testing.T) {
+		var result = parse(t, `{"@context":"https://schema.org","@type":"Article","headline":"Raw","wordCount":1234,"publisher":{"@id":"#org"}}`)
+		assert.Equal(t, float64(1234), result.JSONLD["wordCount"])
+		assert.Equal(t, map[string]interface{}{"@id": "#org"}, result.JSONLD["publisher"])
+	})
+}
@@ -22,7 +22,7 @@
 package readability
 
 import (
-	"log"
+	"fmt"
 	"math"
 	"slices"
 	"strings"
@@ -39,16 +39,71 @@ func isNodeVisible(node *html.Node) bool {
 		(attr(node, "aria-hidden") == "" || attr(node, "aria-hidden") != "true" || (attr(node, "class") != "" && strings.Contains(attr(node, "class"), "fallback-image")))
 }
 
+// Reasons why a node is ignored when computing the readerability score.
+const (
+	RejectedHidden        = "hidden"
+	RejectedUnlikely      = "unlikely"
+	RejectedListParagraph = "li p"
+	RejectedTooShort      = "too short"
+)
+
+// ReportNode describes a node examined by ReaderabilityReport.
+type ReportNode struct {
+	Node *html.Node
+	// length of the trimmed text content of the node
+	TextLength int
+	// contribution of the node to the score of the document
+	Score float64
+	// reason why the node has been rejected, empty for qualifying nodes
+	Reason string
+}
+
+// Report details how the readerability of a document has been decided.
+type Report struct {
+	// accumulated score of the qualifying nodes
+	Score float64
+	// threshold the score must exceed for the document to be readerable
+	MinScore float64
+	// minimum text length of the qualifying nodes
+	MinContentLength int
+	// whether the score exceeds the threshold
+	Readerable bool
+	// nodes which contributed to the score
+	Qualifying []*ReportNode
+	// nodes which have been ignored because hidden, unlikely, inside a list item or too short
+	Rejected []*ReportNode
+}
+
 // Decides whether or not the document is reader-able without parsing the whole thing.
 // Options:
 //   - options.minContentLength (default 140), the minimum node content length used to decide if the document is readerable
 //   - options.minScore (default 20), the minumum cumulated 'score' used to determine if the document is readerable
 //   - options.visibilityChecker (default isNodeVisible), the function used to determine if a node is visible
+//
+// Returns false if the document cannot be parsed.
 func IsProbablyReaderable(htmlSource string, opts ...Option) bool {
+	report, err := readerability(htmlSource, true, opts...)
+	if err != nil {
+		return false
+	}
+	return report.Readerable
+}
+
+// ReaderabilityReport scores the document in the same way as IsProbablyReaderable
+// and reports the nodes taken into account. Unlike IsProbablyReaderable,
+// it does not stop as soon as the threshold is exceeded, so that the
+// returned score is the one of the whole document.
+// It supports the same options as IsProbablyReaderable.
+func ReaderabilityReport(htmlSource string, opts ...Option) (*Report, error) {
+	return readerability(htmlSource, false, opts...)
+}
+
+// Scores the document, stopping as soon as the threshold is exceeded if stopEarly is true.
+func readerability(htmlSource string, stopEarly bool, opts ...Option) (*Report, error) {
 
 	doc, err := html.Parse(strings.NewReader(htmlSource))
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("cannot parse doc: %w", err)
 	}
 
 	var options = defaultOpts()
@@ -64,40 +119,64 @@ func IsProbablyReaderable(htmlSource string, opts ...Option) bool {
 	//   <br>
 	//   Sentences<br>
 	// </div>
+	// The same <div> must be scored only once, whatever the number of its <br> nodes.
 	var brNodes = querySelectorAll(doc, "div > br")
 	if len(brNodes) != 0 {
-		var set []*html.Node
+		var set = make(map[*html.Node]bool, len(nodes))
+		for _, n := range nodes {
+			set[n] = true
+		}
 		for _, n := range brNodes {
-			set = append(set, n.Parent)
+			if !set[n.Parent] {
+				set[n.Parent] = true
+				nodes = append(nodes, n.Parent)
+			}
 		}
-		nodes = append(nodes, set...)
 	}
 
-	var score = 0.0
-	// This is a little cheeky, we use the accumulator 'score' to decide what to return from
-	// this callback:
-	return slices.ContainsFunc(nodes, func(n *html.Node) bool {
+	var report = &Report{
+		MinScore:         options.minScore,
+		MinContentLength: options.minContentLength,
+	}
+
+	var reject = func(n *html.Node, textLength int, reason string) {
+		report.Rejected = append(report.Rejected, &ReportNode{Node: n, TextLength: textLength, Reason: reason})
+	}
+
+	for _, n := range nodes {
 		if !options.visibilityChecker(n) {
-			return false
+			reject(n, 0, RejectedHidden)
+			continue
 		}
 
 		var matchString = attr(n, "class") + " " + attr(n, "id")
 		if unlikelyCandidates.MatchString(matchString) &&
 			!okMaybeItsACandidate.MatchString(matchString) {
-			return false
+			reject(n, 0, RejectedUnlikely)
+			continue
 		}
 
 		if matches(n, "li p") {
-			return false
+			reject(n, 0, RejectedListParagraph)
+			continue
 		}
 
 		var textContentLength = len(strings.TrimSpace(textContent(n)))
 		if textContentLength < options.minContentLength {
-			return false
+			reject(n, textContentLength, RejectedTooShort)
+			continue
 		}
 
-		score += math.Sqrt(float64(textContentLength - options.minContentLength))
+		var score = math.Sqrt(float64(textContentLength - options.minContentLength))
+		report.Score += score
+		report.Qualifying = append(report.Qualifying, &ReportNode{Node: n, TextLength: textContentLength, Score: score})
+
+		if stopEarly && report.Score > report.MinScore {
+			break
+		}
+	}
+
+	report.Readerable = report.Score > report.MinScore
 
-		return score > options.minScore
-	})
+	return report, nil
 }
@@ -0,0 +1,66 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReaderabilityReport(t *testing.T) {
+
+	var paragraph = strings.Repeat("This is a long enough paragraph of text. ", 10)
+
+	t.Run("should report qualifying and rejected nodes", func(t *testing.T) {
+		var source = `<html><body>` +
+			`<p>` + paragraph + `</p>` +
+			`<p>` + paragraph + `</p>` +
+			`<p aria-hidden="true">` + paragraph + `</p>` +
+			`<p class="sidebar">` + paragraph + `</p>` +
+			`<p>short</p>` +
+			`</body></html>`
+
+		report, err := ReaderabilityReport(source)
+		assert.NoError(t, err)
+		assert.Equal(t, 20.0, report.MinScore)
+		assert.Equal(t, 140, report.MinContentLength)
+		assert.Len(t, report.Qualifying, 2)
+		assert.Equal(t, len(strings.TrimSpace(paragraph)), report.Qualifying[0].TextLength)
+		assert.InDelta(t, report.Qualifying[0].Score+report.Qualifying[1].Score, report.Score, 1e-9)
+		assert.True(t, report.Readerable)
+
+		var reasons []string
+		for _, rejected := range report.Rejected {
+			reasons = append(reasons, rejected.Reason)
+		}
+		assert.Equal(t, []string{RejectedHidden, RejectedUnlikely, RejectedTooShort}, reasons)
+	})
+
+	t.Run("should accumulate the score of the whole document", func(t *testing.T) {
+		var source = `<html><body>` + strings.Repeat(`<p>`+paragraph+`</p>`, 5) + `</body></html>`
+
+		report, err := ReaderabilityReport(source, MinScore(1), MinContentLength(100))
+		assert.NoError(t, err)
+		assert.Len(t, report.Qualifying, 5)
+		assert.Equal(t, 1.0, report.MinScore)
+		assert.Equal(t, 100, report.MinContentLength)
+		assert.True(t, report.Readerable)
+	})
+
+	t.Run("should score a div with many br nodes only once", func(t *testing.T) {
+		var source = `<html><body><div>` + paragraph + `<br/>` + paragraph + `<br/>` + paragraph + `<br/></div></body></html>`
+
+		report, err := ReaderabilityReport(source)
+		assert.NoError(t, err)
+		assert.Len(t, report.Qualifying, 1)
+		assert.Equal(t, report.Qualifying[0].Score, report.Score)
+	})
+
+	t.Run("should agree with IsProbablyReaderable", func(t *testing.T) {
+		for _, testPage := range getTestPages() {
+			report, err := ReaderabilityReport(string(testPage.source))
+			assert.NoError(t, err)
+			assert.Equal(t, IsProbablyReaderable(string(testPage.source)), report.Readerable, testPage.dir)
+		}
+	})
+}
@@ -0,0 +1,215 @@
+package readability
+
+import (
+	"html/template"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Theme is the color scheme of a reader view.
+type Theme string
+
+const (
+	ThemeLight Theme = "light"
+	ThemeDark  Theme = "dark"
+	ThemeSepia Theme = "sepia"
+)
+
+// RenderReaderView renders the given article as a standalone HTML5 document looking like
+// the Firefox Reader View, with the given theme: a header with the title, the site name,
+// the byline and the reading time of the article, followed by its content. The document
+// embeds its style sheet and loads no external resources. Unknown themes are rendered as
+// ThemeLight.
+func RenderReaderView(res *Result, theme Theme) string {
+	if theme != ThemeDark && theme != ThemeSepia {
+		theme = ThemeLight
+	}
+	var content = res.HTMLContent
+	if res.Content != nil {
+		// HTMLContent is not HTML with another Serializer.
+		content = res.Content.GetInnerHTML()
+	}
+
+	var siteName = res.SiteName
+	if siteName == "" && res.CanonicalURL != "" {
+		if u, err := url.Parse(res.CanonicalURL); err == nil {
+			siteName = strings.TrimPrefix(u.Hostname(), "www.")
+		}
+	}
+
+	var readingTime string
+	if res.ReadingTime > 0 {
+		var minutes = max(1, int(res.ReadingTime.Round(time.Minute).Minutes()))
+		readingTime = strconv.Itoa(minutes) + " minute"
+		if minutes > 1 {
+			readingTime += "s"
+		}
+	}
+
+	var out strings.Builder
+	// The template is static and its data are strings: it cannot fail.
+	_ = readerViewTemplate.Execute(&out, map[string]any{
+		"Theme":       string(theme),
+		"Lang":        res.Lang,
+		"Dir":         res.Dir,
+		"Title":       res.Title,
+		"Excerpt":     res.Excerpt,
+		"SiteName":    siteName,
+		"URL":         res.CanonicalURL,
+		"Byline":      res.Byline,
+		"ReadingTime": readingTime,
+		"Content":     template.HTML(content),
+	})
+	return out.String()
+}
+
+var readerViewTemplate = template.Must(template.New("reader").Parse(`<!DOCTYPE html>
+<html class="{{.Theme}}"{{with .Lang}} lang="{{.}}"{{end}}{{with .Dir}} dir="{{.}}"{{end}}>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="color-scheme" content="{{if eq .Theme "dark"}}dark{{else}}light{{end}}">
+{{- with .Excerpt}}
+<meta name="description" content="{{.}}">
+{{- end}}
+<title>{{.Title}}</title>
+<style>
+:root {
+  --text: #15141a;
+  --background: #fff;
+  --secondary: #5b5b66;
+  --link: #0060df;
+  --border: #cfcfd8;
+  --code: #f0f0f4;
+}
+.dark {
+  --text: #fbfbfe;
+  --background: #1c1b22;
+  --secondary: #bfbfc9;
+  --link: #45a1ff;
+  --border: #52525e;
+  --code: #2b2a33;
+}
+.sepia {
+  --text: #5b4636;
+  --background: #f4ecd8;
+  --secondary: #80705f;
+  --link: #0060df;
+  --border: #d6c8a9;
+  --code: #eadfc4;
+}
+html {
+  background: var(--background);
+  color: var(--text);
+}
+body {
+  margin: 0 auto;
+  padding: 64px 24px;
+  max-width: 36em;
+  font-family: system-ui, -apple-system, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif;
+  font-size: 20px;
+  line-height: 1.6;
+  overflow-wrap: break-word;
+}
+a {
+  color: var(--link);
+}
+.header {
+  margin-bottom: 2em;
+  padding-bottom: 1em;
+  border-bottom: 1px solid var(--border);
+}
+.site-name {
+  display: block;
+  margin-bottom: 0.5em;
+  font-size: 0.9em;
+  color: var(--link);
+  text-decoration: none;
+}
+h1.title {
+  margin: 0 0 0.5em;
+  font-size: 1.6em;
+  line-height: 1.25;
+}
+.byline, .reading-time {
+  margin: 0.25em 0;
+  font-size: 0.9em;
+  color: var(--secondary);
+}
+.byline {
+  font-style: italic;
+}
+.content h1, .content h2, .content h3, .content h4, .content h5, .content h6 {
+  line-height: 1.25;
+}
+.content img, .content video, .content iframe, .content embed, .content object {
+  max-width: 100%;
+  height: auto;
+}
+.content iframe {
+  width: 100%;
+  aspect-ratio: 16 / 9;
+  border: 0;
+}
+.content figure {
+  margin: 1.5em 0;
+}
+.content figcaption {
+  font-size: 0.85em;
+  color: var(--secondary);
+}
+.content blockquote {
+  margin: 1em 0;
+  padding-inline-start: 1em;
+  border-inline-start: 3px solid var(--border);
+  color: var(--secondary);
+}
+.content pre, .content code {
+  font-family: ui-monospace, Menlo, Consolas, monospace;
+  font-size: 0.85em;
+  background: var(--code);
+}
+.content pre {
+  padding: 1em;
+  overflow: auto;
+}
+.content pre code {
+  font-size: 1em;
+  background: none;
+}
+.content table {
+  display: block;
+  overflow: auto;
+  border-collapse: collapse;
+}
+.content td, .content th {
+  padding: 0.25em 0.5em;
+  border: 1px solid var(--border);
+}
+</style>
+</head>
+<body>
+<div class="header">
+{{- if .SiteName}}
+{{- if .URL}}
+<a class="site-name" href="{{.URL}}">{{.SiteName}}</a>
+{{- else}}
+<span class="site-name">{{.SiteName}}</span>
+{{- end}}
+{{- end}}
+<h1 class="title">{{.Title}}</h1>
+{{- with .Byline}}
+<p class="byline">{{.}}</p>
+{{- end}}
+{{- with .ReadingTime}}
+<p class="reading-time">{{.}}</p>
+{{- end}}
+</div>
+<div class="content">
+{{.Content}}
+</div>
+</body>
+</html>
+`))
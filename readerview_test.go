@@ -0,0 +1,51 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderReaderView(t *testing.T) {
+
+	var paragraph = "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5) + "</p>"
+	var html = `<html lang="he" dir="rtl"><head><title>Tom &amp; Jerry</title><meta name="author" content="Jane &lt;Doe&gt;" />` +
+		`<meta property="og:site_name" content="The Site" /><link rel="canonical" href="http://fakehost/story" /></head>` +
+		`<body><article><h1>Tom &amp; Jerry</h1>` + paragraph + `<img src="cat.jpg" alt="A cat" />` + paragraph + `</article></body></html>`
+
+	reader, err := New(html, "http://fakehost/test/page.html", Serializer(Markdown))
+	assert.NoError(t, err)
+	result, err := reader.Parse()
+	assert.NoError(t, err)
+
+	var doc = RenderReaderView(result, ThemeSepia)
+	assert.True(t, strings.HasPrefix(doc, "<!DOCTYPE html>\n<html class=\"sepia\" lang=\"he\" dir=\"rtl\">"))
+	assert.Contains(t, doc, "<title>Tom &amp; Jerry</title>")
+	assert.Contains(t, doc, `<a class="site-name" href="http://fakehost/story">The Site</a>`)
+	assert.Contains(t, doc, `<h1 class="title">Tom &amp; Jerry</h1>`)
+	assert.Contains(t, doc, `<p class="byline">Jane &lt;Doe&gt;</p>`)
+	assert.Contains(t, doc, `<p class="reading-time">1 minute</p>`)
+	assert.Contains(t, doc, ".sepia {")
+	// The content is HTML whatever the serializer.
+	assert.Contains(t, doc, `<img src="http://fakehost/test/cat.jpg" alt="A cat"/>`)
+	assert.NotContains(t, doc, "http://fonts")
+	assert.NotContains(t, doc, "<script")
+	assert.NotContains(t, doc, "<link")
+
+	t.Run("minimal result", func(t *testing.T) {
+		var doc = RenderReaderView(&Result{Title: "Title", HTMLContent: "<p>Text</p>", ReadingTime: 150 * time.Second}, "unknown")
+		assert.True(t, strings.HasPrefix(doc, "<!DOCTYPE html>\n<html class=\"light\">"))
+		assert.NotContains(t, doc, "site-name\"")
+		assert.NotContains(t, doc, "byline\"")
+		assert.Contains(t, doc, `<p class="reading-time">3 minutes</p>`)
+		assert.Contains(t, doc, "<div class=\"content\">\n<p>Text</p>\n</div>")
+	})
+
+	t.Run("unsafe URLs", func(t *testing.T) {
+		var doc = RenderReaderView(&Result{Title: "Title", SiteName: "Site", CanonicalURL: "javascript:alert(1)", HTMLContent: "<p>Text</p>"}, ThemeDark)
+		assert.NotContains(t, doc, "javascript:")
+		assert.Contains(t, doc, `<meta name="color-scheme" content="dark">`)
+	})
+}
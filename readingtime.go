@@ -0,0 +1,133 @@
+package readability
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+// Average reading speeds, mostly from Trauzettel-Klosinski et al., "Standardized
+// Assessment of Reading Performance: The New International Reading Speed Texts IReST",
+// in words per minute or, for the languages written without spaces between words,
+// in characters per minute. The empty language is the speed of the other languages.
+var defaultReadingSpeeds = map[string]int{
+	"":   228,
+	"ar": 138,
+	"de": 179,
+	"en": 228,
+	"es": 218,
+	"fi": 161,
+	"fr": 195,
+	"he": 187,
+	"it": 188,
+	"ja": 357,
+	"nl": 202,
+	"pl": 166,
+	"pt": 181,
+	"ru": 184,
+	"sl": 180,
+	"sv": 199,
+	"th": 300,
+	"tr": 166,
+	"zh": 255,
+}
+
+// Counts the words of the given text, as segmented by the Unicode word boundaries (UAX #29).
+// Without a dictionary, the segmentation splits the scripts written without spaces between
+// words, as Chinese, Japanese and Thai, into characters: these are counted apart, by language,
+// as their reading speed is given in characters per minute.
+func countWords(text string) (words int, chars map[string]int) {
+	chars = make(map[string]int)
+	var kana bool
+	var segment string
+	var state = -1
+	for len(text) > 0 {
+		segment, text, state = uniseg.FirstWordInString(text, state)
+		var isWord, isChars bool
+		for _, r := range segment {
+			switch {
+			case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana):
+				kana = kana || !unicode.Is(unicode.Han, r)
+				chars["zh"]++
+				isChars = true
+			case unicode.Is(unicode.Thai, r):
+				chars["th"]++
+				isChars = true
+			case isWordRune(r):
+				isWord = true
+			}
+		}
+		// Segments of punctuation and white space are not words.
+		if isWord && !isChars {
+			words++
+		}
+	}
+	// Japanese mixes kanji with kana.
+	if kana {
+		chars["ja"], chars["zh"] = chars["zh"], 0
+	}
+	return words, chars
+}
+
+// Checks whether the given rune can be part of a word. Marks are included
+// for the vowels and diacritics of scripts such as Arabic and Hebrew.
+func isWordRune(r rune) bool {
+	return unicode.In(r, unicode.L, unicode.M, unicode.Nd)
+}
+
+// Estimates the time needed to read the given number of words and characters, in the given language.
+func (r *Readability) readingTime(words int, chars map[string]int, lang string) time.Duration {
+	var minutes = float64(words) / float64(r.readingSpeed(lang))
+	for charsLang, count := range chars {
+		// Prefer the document language, more specific, e.g. zh-TW.
+		if primaryLanguage(lang) == charsLang {
+			charsLang = lang
+		}
+		minutes += float64(count) / float64(r.readingSpeed(charsLang))
+	}
+	return time.Duration(minutes * float64(time.Minute)).Round(time.Second)
+}
+
+// Returns the reading speed of the given language, set by the options or the default one.
+func (r *Readability) readingSpeed(lang string) int {
+	for _, l := range []string{lang, primaryLanguage(lang), ""} {
+		if speed := r.options.readingSpeeds[l]; speed > 0 {
+			return speed
+		}
+		if speed := defaultReadingSpeeds[l]; speed > 0 {
+			return speed
+		}
+	}
+	return defaultReadingSpeeds[""]
+}
+
+// Returns the primary language subtag of the given language tag, in lower case, e.g. "en" for "en-US".
+func primaryLanguage(lang string) string {
+	var primary, _, _ = strings.Cut(strings.ReplaceAll(lang, "_", "-"), "-")
+	return strings.ToLower(strings.TrimSpace(primary))
+}
+
+// Returns the text of the given article content, without code blocks and figure captions.
+func readableText(n *Node) string {
+	var text strings.Builder
+	var collect func(n *Node)
+	collect = func(n *Node) {
+		for _, child := range n.ChildNodes {
+			switch {
+			case child.NodeType == textNode:
+				text.WriteString(child.GetTextContent())
+			case child.TagName != "PRE" && child.TagName != "FIGCAPTION":
+				collect(child)
+			}
+			// Blocks separate words, e.g. paragraphs without white space between them.
+			if child.NodeType == elementNode && !slices.Contains(phrasingElems, child.TagName) {
+				text.WriteString(" ")
+			}
+		}
+	}
+	collect(n)
+	return text.String()
+}
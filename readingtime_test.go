@@ -0,0 +1,76 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountWords(t *testing.T) {
+	testCases := []struct {
+		text  string
+		words int
+		chars map[string]int
+	}{
+		{"The quick brown fox jumps over the lazy dog.", 9, map[string]int{}},
+		{"  Don't count 3.14 or 1,000 as two words — nor l'homme. ", 10, map[string]int{}},
+		{"Hyphens separate words, as in well-known.", 7, map[string]int{}},
+		{"Übermäßig große Bäume", 3, map[string]int{}},
+		{"الثعلب البني السريع يقفز", 4, map[string]int{}},
+		{"שָׁלוֹם עוֹלָם", 2, map[string]int{}},
+		{"敏捷的棕色狐狸", 0, map[string]int{"zh": 7}},
+		{"素早い茶色の狐", 0, map[string]int{"ja": 7, "zh": 0}},
+		{"สวัสดีครับ", 0, map[string]int{"th": 10}},
+		{"Go语言很好", 1, map[string]int{"zh": 4}},
+		{"我爱北京天安门。你呢？", 0, map[string]int{"zh": 9}},
+		{"カタカナとひらがなと漢字", 0, map[string]int{"ja": 12, "zh": 0}},
+		{"ภาษาไทย ง่ายมาก 2567", 1, map[string]int{"th": 14}},
+		{"", 0, map[string]int{}},
+	}
+	for _, tc := range testCases {
+		var words, chars = countWords(tc.text)
+		assert.Equal(t, tc.words, words, tc.text)
+		assert.Equal(t, tc.chars, chars, tc.text)
+	}
+}
+
+func TestReadingTime(t *testing.T) {
+
+	const uri = "http://fakehost/test/page.html"
+
+	var paragraph = "<p>" + strings.TrimSpace(strings.Repeat("word ", 100)) + "</p>"
+
+	var parse = func(t *testing.T, html string, opts ...Option) *Result {
+		reader, err := New(html, uri, opts...)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	t.Run("should count the words of the article", func(t *testing.T) {
+		var result = parse(t, `<html lang="en"><body><article>`+strings.Repeat(paragraph, 5)+
+			`<pre><code>func main() { fmt.Println("these words are not counted") }</code></pre>`+
+			`<figure><img src="fox.jpg" /><figcaption>Neither are these ones</figcaption></figure></article></body></html>`)
+		assert.Equal(t, 500, result.WordCount)
+		assert.Equal(t, 2*time.Minute+12*time.Second, result.ReadingTime)
+	})
+
+	t.Run("should use the reading speed of the language", func(t *testing.T) {
+		var result = parse(t, `<html lang="de-AT"><body><article>`+strings.Repeat(paragraph, 5)+`</article></body></html>`)
+		assert.Equal(t, 2*time.Minute+48*time.Second, result.ReadingTime)
+
+		result = parse(t, `<html lang="de-AT"><body><article>`+strings.Repeat(paragraph, 5)+`</article></body></html>`,
+			ReadingSpeed("de", 250))
+		assert.Equal(t, 2*time.Minute, result.ReadingTime)
+	})
+
+	t.Run("should count the characters of Chinese", func(t *testing.T) {
+		var result = parse(t, `<html lang="zh-CN"><body><article>`+strings.Repeat("<p>"+strings.Repeat("敏捷的棕色狐狸跳过了懒惰的狗。", 17)+"</p>", 3)+`</article></body></html>`,
+			ReadingSpeed("zh", 357))
+		assert.Equal(t, 714, result.WordCount)
+		assert.Equal(t, 2*time.Minute, result.ReadingTime)
+	})
+}
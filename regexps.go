@@ -9,20 +9,26 @@ var (
 	okMaybeItsACandidate = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
 	positive             = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|pagination|post|text|blog|story`)
 	negative             = regexp.MustCompile(`(?i)-ad-|hidden|^hid$| hid$| hid |^hid |banner|combx|comment|com-|contact|foot|footer|footnote|gdpr|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
-	//extraneous           = regexp.MustCompile(`(?i)print|archive|comment|discuss|e[\-]?mail|share|reply|all|login|sign|single|utility`)
-	byline = regexp.MustCompile(`(?i)byline|author|dateline|writtenby|p-author`)
+	extraneous           = regexp.MustCompile(`(?i)print|archive|comment|discuss|e[\-]?mail|share|reply|all|login|sign|single|utility`)
+	byline               = regexp.MustCompile(`(?i)byline|author|dateline|writtenby|p-author`)
 	//replaceFonts         = regexp.MustCompile(`(?i)<(\/?)font[^>]*>`)
 	normalize     = regexp.MustCompile(`\s{2,}`)
 	videos        = regexp.MustCompile(`(?i)\/\/(www\.)?((dailymotion|youtube|youtube-nocookie|player\.vimeo|v\.qq)\.com|(archive|upload\.wikimedia)\.org|player\.twitch\.tv)`)
 	shareElements = regexp.MustCompile(`(?i)(\b|_)(share|sharedaddy)(\b|_)`)
-	//nextLink             = regexp.MustCompile(`(?i)(next|weiter|continue|>([^\|]|$)|»([^\|]|$))`)
-	//prevLink             = regexp.MustCompile(`(prev|earl|old|new|<|«)`)
-	tokenize   = regexp.MustCompile(`\W+`)
-	whitespace = regexp.MustCompile(`^\s*$`)
-	hasContent = regexp.MustCompile(`\S$`)
-	hashUrl    = regexp.MustCompile(`^#.+`)
-	srcsetUrl  = regexp.MustCompile(`(\S+)(\s+[\d.]+[xw])?(\s*(?:,|$))`)
-	b64DataUrl = regexp.MustCompile(`(?i)^data:\s*([^\s;,]+)\s*;\s*base64\s*,`)
+	nextLink      = regexp.MustCompile(`(?i)(next|weiter|continue|>([^\|]|$)|»([^\|]|$))`)
+	prevLink      = regexp.MustCompile(`(?i)(prev|earl|old|new|<|«)`)
+	// links and blocks of the pagination of multi-page articles
+	pagination      = regexp.MustCompile(`(?i)pag(e|ing|inat)`)
+	firstOrLastLink = regexp.MustCompile(`(?i)(first|last)`)
+	pageNumberURL   = regexp.MustCompile(`(?i)p(a|g|ag)?(e|ing|ination)?(=|\/)[0-9]{1,2}|(page|paging)`)
+	// page numbers found at the end of the URLs of the next pages, e.g. "/2", "/page-2" or "/p2"
+	pageNumberSegment = regexp.MustCompile(`(?i)^(?:p|pg|page|paging)?[-_]?\d{1,3}$`)
+	tokenize          = regexp.MustCompile(`\W+`)
+	whitespace        = regexp.MustCompile(`^\s*$`)
+	hasContent        = regexp.MustCompile(`\S$`)
+	hashUrl           = regexp.MustCompile(`^#.+`)
+	srcsetUrl         = regexp.MustCompile(`(\S+)(\s+[\d.]+[xw])?(\s*(?:,|$))`)
+	b64DataUrl        = regexp.MustCompile(`(?i)^data:\s*([^\s;,]+)\s*;\s*base64\s*,`)
 	// commas as used in Latin, Sindhi, Chinese and various other scripts.
 	// see: https://en.wikipedia.org/wiki/Comma#Comma_variants
 	commas = regexp.MustCompile(`\x{002C}|\x{060C}|\x{FE50}|\x{FE10}|\x{FE11}|\x{2E41}|\x{2E34}|\x{2E32}|\x{FF0C}`)
@@ -44,11 +50,25 @@ var (
 	cdata                = regexp.MustCompile(`^\s*<!\[CDATA\[|\]\]>\s*$`)
 	schemaUrl            = regexp.MustCompile(`^https?\:\/\/schema\.org\/?$`)
 	// property is a space-separated list of values
-	propertyPattern = regexp.MustCompile(`(?i)\s*(article|dc|dcterm|og|twitter)\s*:\s*(author|creator|description|published_time|title|site_name)\s*`)
+	propertyPattern = regexp.MustCompile(`(?i)\s*(article|dc|dcterm|og|twitter)\s*:\s*(author|creator|description|published_time|modified_time|updated_time|title|site_name|url|image(?:\s*:\s*(?:url|secure_url|width|height|alt))?|tag|section|locale(?:\s*:\s*alternate)?)\s*`)
 	// name is a single value
-	namePattern                   = regexp.MustCompile(`(?i)^\s*(?:(dc|dcterm|og|twitter|weibo:(article|webpage))\s*[\.:]\s*)?(author|creator|description|title|site_name)\s*$`)
+	namePattern = regexp.MustCompile(`(?i)^\s*(?:(?:(dc|dcterm|og|twitter|weibo:(article|webpage))\s*[\.:]\s*)?(author|creator|description|title|site_name|image(?:[\.:](?:src|width|height|alt))?|keywords|news_keywords)|(?:dc|dcterm)\s*[\.:]\s*(?:identifier|publisher|date(?:[\.:]issued)?)|citation_(?:title|author|doi|pdf_url|journal_title|publisher|publication_date|date|volume|issue|firstpage|lastpage))\s*$`)
+	// DOIs, bare or prefixed with "doi:" or a DOI resolver
+	doiPattern                    = regexp.MustCompile(`(?i)^(?:doi:\s*|https?://(?:dx\.)?doi\.org/)?(10\.\d{4,9}/\S+)$`)
 	imgExtensions                 = regexp.MustCompile(`\.(jpg|jpeg|png|webp)`)
 	base64Starts                  = regexp.MustCompile(`base64\s*`)
 	imgExtensionsWithSpacesAndNum = regexp.MustCompile(`\.(jpg|jpeg|png|webp)\s+\d`)
 	imgExtensionsAmongText        = regexp.MustCompile(`^\s*\S+\.(jpg|jpeg|png|webp)\S*\s*$`)
+	// image URLs of known tracking services, or named like spacers and beacons
+	trackingPixels = regexp.MustCompile(`(?i)^(?:https?:)?//(?:[^/?#]*\.)?(?:google-analytics\.com|doubleclick\.net|scorecardresearch\.com|quantserve\.com|pixel\.wp\.com|stats\.wp\.com|bat\.bing\.com|px\.ads\.linkedin\.com|ct\.pinterest\.com)(?:[/?#:]|$)|^(?:https?:)?//(?:www\.)?facebook\.com/tr(?:[/?#]|$)|(?:^|/)(?:pixel|beacon|spacer|blank|transparent|clear|1x1|tracking|tracker)\.(?:gif|png)(?:[?#]|$)`)
+	// noise found in bylines
+	bylineSeparators       = regexp.MustCompile(`\s*(?:[\n\r|•·—–]|\s-\s)\s*`)
+	bylinePrefix           = regexp.MustCompile(`(?i)^(?:(?:written|posted|reported|words)\s+)?(?:by|von|par|por|door)\b\s*:?\s*`)
+	bylineDates            = regexp.MustCompile(`(?i)(?:\b(?:(?:updated|published|posted|modified)\s*:?\s*)?(?:(?:on|le|am)\s+)?(?:(?:mon|tue|wed|thu|fri|sat|sun)[a-z]*\.?,?\s+)?)?(?:\d{1,2}(?:st|nd|rd|th)?\.?\s+(?:of\s+)?(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?,?\s+\d{4}|\b(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?\s+\d{1,2}(?:st|nd|rd|th)?,?\s+\d{4}|\d{4}-\d{1,2}-\d{1,2}|\d{1,2}[./]\d{1,2}[./]\d{2,4})(?:,?\s*(?:at|um|à)?\s*\d{1,2}[:h.]\d{2}(?::\d{2})?\s*(?:[ap]\.?m\.?)?(?:\s*(?:UTC|GMT|[ECMP][SD]T)\b)?)?|\b\d+\s+(?:second|minute|hour|day|week|month|year)s?\s+ago\b|\b(?:updated|published|posted)\b`)
+	bylineParentheses      = regexp.MustCompile(`\([^()]*\)`)
+	bylineURL              = regexp.MustCompile(`https?://[^\s()<>]+`)
+	bylineEmail            = regexp.MustCompile(`[\w.+-]+@[\w-]+(?:\.[\w-]+)+`)
+	bylineAuthorSeparators = regexp.MustCompile(`(?i)\s*,\s*|\s+(?:and|&|und|et|y)\s+`)
+	// dates in URL paths, as in /2015/03/25/ or /2015-03-25-slug
+	urlDatePattern = regexp.MustCompile(`/((?:19|20)\d{2})[/\-](0[1-9]|1[0-2])[/\-](0[1-9]|[12]\d|3[01])(?:[/\-_.]|$)`)
 )
@@ -0,0 +1,163 @@
+package readability
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Policy is an allowlist of the elements, attributes and URL schemes kept in the article
+// content by the Sanitize option. Element and attribute names are in lower case.
+// The event handler attributes, e.g. onclick, are never kept.
+type Policy struct {
+	// elements kept, e.g. "p"; the other ones are replaced by their content
+	Elements []string
+	// elements removed with their content, e.g. "script"
+	RemovedElements []string
+	// attributes kept on all the elements, e.g. "title"
+	GlobalAttributes []string
+	// attributes kept by element, e.g. "href" for "a"
+	Attributes map[string][]string
+	// schemes of the URLs kept in the attributes holding URLs, e.g. "https";
+	// relative URLs are always kept
+	URLSchemes []string
+}
+
+// Attributes holding URLs, whose schemes are checked.
+var urlAttributes = []string{
+	"action", "background", "cite", "data", "formaction", "href", "longdesc", "poster", "src", "xlink:href",
+}
+
+var (
+	// style attributes loading resources or running scripts
+	unsafeStyle = regexp.MustCompile(`(?i)expression|javascript:|vbscript:|url\(|@import|behavior|-moz-binding`)
+	cssComments = regexp.MustCompile(`/\*.*?\*/`)
+	urlScheme   = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*):`)
+)
+
+// DefaultPolicy returns the policy keeping the text-level and grouping elements, tables,
+// images, videos and sounds, with the attributes needed to render them, and the http,
+// https and mailto URLs. Scripts, styles, SVG and MathML, frames, objects and forms are
+// removed. The returned policy can be modified.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Elements: []string{
+			"a", "abbr", "address", "article", "aside", "audio", "b", "bdi", "bdo", "blockquote",
+			"br", "caption", "cite", "code", "col", "colgroup", "dd", "del", "details", "dfn",
+			"div", "dl", "dt", "em", "figcaption", "figure", "footer", "h1", "h2", "h3", "h4",
+			"h5", "h6", "header", "hr", "i", "img", "ins", "kbd", "li", "main", "mark", "ol",
+			"p", "picture", "pre", "q", "rp", "rt", "ruby", "s", "samp", "section", "small",
+			"source", "span", "strong", "sub", "summary", "sup", "table", "tbody", "td",
+			"tfoot", "th", "thead", "time", "tr", "track", "u", "ul", "var", "video", "wbr",
+		},
+		RemovedElements: []string{
+			"applet", "base", "button", "canvas", "dialog", "embed", "form", "frame", "frameset",
+			"head", "iframe", "input", "link", "math", "meta", "noscript", "object", "option",
+			"script", "select", "style", "svg", "template", "textarea", "title",
+		},
+		GlobalAttributes: []string{"class", "dir", "id", "lang", "title"},
+		Attributes: map[string][]string{
+			"a":          {"href", "hreflang", "rel"},
+			"audio":      {"controls", "loop", "muted", "preload", "src"},
+			"blockquote": {"cite"},
+			"col":        {"span"},
+			"colgroup":   {"span"},
+			"del":        {"cite", "datetime"},
+			"details":    {"open"},
+			"img":        {"alt", "height", "loading", "sizes", "src", "srcset", "width"},
+			"ins":        {"cite", "datetime"},
+			"li":         {"value"},
+			"ol":         {"reversed", "start", "type"},
+			"q":          {"cite"},
+			"source":     {"media", "sizes", "src", "srcset", "type"},
+			"td":         {"colspan", "headers", "rowspan"},
+			"th":         {"abbr", "colspan", "headers", "rowspan", "scope"},
+			"time":       {"datetime"},
+			"track":      {"default", "kind", "label", "src", "srclang"},
+			"video":      {"controls", "height", "loop", "muted", "poster", "preload", "src", "width"},
+		},
+		URLSchemes: []string{"http", "https", "mailto"},
+	}
+}
+
+// Removes from the children of the given node the elements, attributes and URLs
+// which are not allowed by the policy.
+func (p *Policy) sanitize(n *Node) {
+	var children []*Node
+	for _, child := range slices.Clone(n.ChildNodes) {
+		children = append(children, p.sanitizeNode(child)...)
+	}
+	for n.FirstChild() != nil {
+		_, _ = n.RemoveChild(n.FirstChild())
+	}
+	for _, child := range children {
+		n.AppendChild(child)
+	}
+}
+
+// Returns the nodes replacing the given one in the sanitized content.
+func (p *Policy) sanitizeNode(n *Node) []*Node {
+	if n.NodeType == textNode {
+		// The inner HTML of a text node can be set without being escaped.
+		n.SetTextContent(n.GetTextContent())
+		return []*Node{n}
+	}
+	if n.NodeType != elementNode {
+		return nil
+	}
+	var tag = strings.ToLower(n.TagName)
+	if slices.Contains(p.RemovedElements, tag) {
+		return nil
+	}
+	p.sanitize(n)
+	if !slices.Contains(p.Elements, tag) {
+		return slices.Clone(n.ChildNodes)
+	}
+	for _, name := range n.GetAttributeNames() {
+		if !p.allowsAttribute(tag, name, n.GetAttribute(name)) {
+			n.RemoveAttribute(name)
+		}
+	}
+	return []*Node{n}
+}
+
+// Checks whether the given attribute of the given element is allowed, with its value.
+func (p *Policy) allowsAttribute(tag, name, value string) bool {
+	name = strings.ToLower(name)
+	if strings.HasPrefix(name, "on") {
+		return false
+	}
+	if !slices.Contains(p.GlobalAttributes, name) && !slices.Contains(p.Attributes[tag], name) {
+		return false
+	}
+	switch {
+	case slices.Contains(urlAttributes, name):
+		return p.allowsURL(value)
+	case name == "srcset":
+		for _, candidate := range strings.Split(value, ",") {
+			if fields := strings.Fields(candidate); len(fields) > 0 && !p.allowsURL(fields[0]) {
+				return false
+			}
+		}
+	case name == "style":
+		var style = strings.Join(strings.Fields(cssComments.ReplaceAllString(strings.ReplaceAll(value, `\`, ""), "")), "")
+		return !unsafeStyle.MatchString(style)
+	}
+	return true
+}
+
+// Checks whether the given URL is relative or has an allowed scheme.
+func (p *Policy) allowsURL(uri string) bool {
+	// Browsers ignore the white space and the control characters of URLs, e.g. in "java\tscript:".
+	uri = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, uri)
+	var match = urlScheme.FindStringSubmatch(uri)
+	if match == nil {
+		return true
+	}
+	return slices.Contains(p.URLSchemes, strings.ToLower(match[1]))
+}
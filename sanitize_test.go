@@ -0,0 +1,98 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+
+	var sanitize = func(policy *Policy, html string) string {
+		var doc = newDOMParser().parse("<html><body>"+html+"</body></html>", "http://fakehost/")
+		policy.sanitize(doc.Body)
+		return doc.Body.GetInnerHTML()
+	}
+
+	testCases := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"event handlers", `<img src="x.png" onerror="alert(1)" OnLoad="alert(1)" />`, `<img src="x.png"/>`},
+		{"javascript URLs", `<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"obfuscated javascript URLs", "<a href=\" JaVa\tScRiPt:alert(1)\">x</a><a href=\"&#106;avascript:alert(1)\">y</a>", `<a>x</a><a>y</a>`},
+		{"vbscript URLs", `<a href="vbscript:msgbox(1)">x</a>`, `<a>x</a>`},
+		{"data URLs", `<img src="data:image/svg+xml;base64,PHN2Zz48L3N2Zz4=" alt="x" />`, `<img alt="x"/>`},
+		{"data URLs in srcset", `<img src="https://fakehost/a.png" srcset="data:image/png;base64,AAAA 1x, https://fakehost/b.png 2x" />`, `<img src="https://fakehost/a.png"/>`},
+		{"svg scripts", `<p>a<svg><script>alert(1)</script><a xlink:href="javascript:alert(1)"><text>x</text></a></svg>b</p>`, `<p>ab</p>`},
+		{"math", `<math><mi xlink:href="javascript:alert(1)">x</mi></math>`, ``},
+		{"frames", `<iframe src="https://www.youtube.com/embed/xyz"></iframe><iframe srcdoc="&lt;script&gt;alert(1)&lt;/script&gt;"></iframe>`, ``},
+		{"objects", `<object data="javascript:alert(1)"></object><embed src="javascript:alert(1)" />`, ``},
+		{"forms", `<form action="javascript:alert(1)"><input autofocus="" onfocus="alert(1)" /><button formaction="javascript:alert(1)">x</button></form>`, ``},
+		{"scripts and styles", `<script>alert(1)</script><style>body { background: url(javascript:alert(1)) }</style><noscript><p>No script</p></noscript><p>Text</p>`, `<p>Text</p>`},
+		{"style attributes", `<p style="background: url(javascript:alert(1))">x</p>`, `<p>x</p>`},
+		{"unknown elements", `<div><custom-element onclick="alert(1)">text <b>bold</b></custom-element></div>`, `<div>text <b>bold</b></div>`},
+		{"media", `<video poster="javascript:alert(1)" src="https://fakehost/v.mp4" onloadstart="alert(1)" controls="" autoplay=""></video>`, `<video src="https://fakehost/v.mp4" controls=""></video>`},
+		{"links", `<a href="https://fakehost/" target="_blank" rel="noopener">x</a> <a href="/relative">y</a> <a href="mailto:jane@fakehost">z</a>`, `<a href="https://fakehost/" rel="noopener">x</a> <a href="/relative">y</a> <a href="mailto:jane@fakehost">z</a>`},
+		{"escaped text", `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`, `<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`},
+		{"attributes of other elements", `<p href="https://fakehost/" src="x.png" class="caption" title="T">x</p>`, `<p class="caption" title="T">x</p>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, sanitize(DefaultPolicy(), tc.html))
+		})
+	}
+
+	t.Run("allowed style attributes", func(t *testing.T) {
+		var policy = DefaultPolicy()
+		policy.GlobalAttributes = append(policy.GlobalAttributes, "style")
+		assert.Equal(t, `<span style="color: red">a</span><span>b</span><span>c</span>`, sanitize(policy,
+			`<span style="color: red">a</span><span style="width: expr/**/ession(alert(1))">b</span><span style="background: u\rl(x.png)">c</span>`))
+	})
+
+	t.Run("event handlers cannot be allowed", func(t *testing.T) {
+		var policy = DefaultPolicy()
+		policy.GlobalAttributes = append(policy.GlobalAttributes, "onclick")
+		assert.Equal(t, `<p>x</p>`, sanitize(policy, `<p onclick="alert(1)">x</p>`))
+	})
+
+	t.Run("text nodes with raw inner HTML", func(t *testing.T) {
+		var doc = newDOMParser().parse("<html><body><p>x</p></body></html>", "http://fakehost/")
+		var p = doc.getElementsByTagName("p")[0]
+		p.FirstChild().setInnerHTMLFromTextNode("<script>alert(1)</script>")
+		DefaultPolicy().sanitize(doc.Body)
+		assert.NotContains(t, doc.Body.GetInnerHTML(), "<script>")
+	})
+}
+
+func TestSanitizeOption(t *testing.T) {
+
+	var paragraph = "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5) + "</p>"
+	var html = `<html><body><article><h1>Title</h1>` + paragraph +
+		`<p><a href="https://fakehost/page" onclick="alert(1)">a link</a> and <a href="data:text/html,&lt;script&gt;alert(1)&lt;/script&gt;">a data link</a>.</p>` +
+		`<iframe src="https://www.youtube.com/embed/xyz" width="560" height="315"></iframe>` + paragraph +
+		`</article></body></html>`
+
+	var parse = func(opts ...Option) *Result {
+		reader, err := New(html, "http://fakehost/test/page.html", opts...)
+		assert.NoError(t, err)
+		result, err := reader.Parse()
+		assert.NoError(t, err)
+		return result
+	}
+
+	var unsanitized = parse()
+	assert.Contains(t, unsanitized.HTMLContent, "onclick")
+	assert.Contains(t, unsanitized.HTMLContent, "<iframe")
+
+	var result = parse(Sanitize(DefaultPolicy()))
+	assert.NotContains(t, result.HTMLContent, "onclick")
+	assert.NotContains(t, result.HTMLContent, "<iframe")
+	assert.NotContains(t, result.HTMLContent, "data:")
+	assert.Contains(t, result.HTMLContent, `<a href="https://fakehost/page">a link</a> and <a>a data link</a>.`)
+	assert.Equal(t, []Link{{Href: "https://fakehost/page", Text: "a link", IsInternal: true}}, result.Links)
+	assert.Empty(t, result.Media)
+}
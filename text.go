@@ -0,0 +1,258 @@
+package readability
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TextRenderer renders article content as plain text keeping its structure: blocks are
+// separated by blank lines, list items keep their markers, blockquotes are indented and
+// preformatted text is kept as is. Its Render method can be used with the TextSerializer option.
+type TextRenderer struct {
+	// column at which the lines are wrapped, 0 to not wrap them
+	Width int
+}
+
+// PlainText renders the given article content as plain text, without wrapping its lines.
+// It can be used with the TextSerializer option, e.g. TextSerializer(PlainText).
+func PlainText(n *Node) string {
+	return TextRenderer{}.Render(n)
+}
+
+// Render renders the given article content as plain text.
+func (t TextRenderer) Render(n *Node) string {
+	var w = &textWriter{width: t.Width}
+	w.children(n)
+	return w.String()
+}
+
+// Indentation of blockquotes.
+const textQuoteIndent = "    "
+
+// Writes the plain text of a node tree. The inline content of a block is
+// collected in line, and written to out, wrapped, when the block ends.
+type textWriter struct {
+	out   bytes.Buffer
+	line  strings.Builder
+	width int
+}
+
+// Returns a writer for the content of an element, to be indented.
+func (w *textWriter) sub(indent int) *textWriter {
+	var width = w.width
+	if width > 0 {
+		// Narrow blocks are still readable.
+		width = max(width-indent, 20)
+	}
+	return &textWriter{width: width}
+}
+
+// Returns the text written.
+func (w *textWriter) String() string {
+	w.flush()
+	return w.out.String()
+}
+
+// Writes the inline content collected as a block.
+func (w *textWriter) flush() {
+	var lines = strings.Split(w.line.String(), "\n")
+	w.line.Reset()
+	for i, line := range lines {
+		lines[i] = wrapLine(strings.TrimSpace(line), w.width)
+	}
+	var text = strings.Trim(strings.Join(lines, "\n"), "\n")
+	if text == "" {
+		return
+	}
+	if w.out.Len() > 0 {
+		w.out.WriteString("\n\n")
+	}
+	w.out.WriteString(text)
+}
+
+// Writes the given block as is, e.g. a list or preformatted text.
+func (w *textWriter) block(s string) {
+	w.flush()
+	if strings.TrimSpace(s) == "" {
+		return
+	}
+	if w.out.Len() > 0 {
+		w.out.WriteString("\n\n")
+	}
+	w.out.WriteString(s)
+}
+
+// Writes the given inline text, with its white space collapsed.
+func (w *textWriter) text(s string) {
+	s = multipleWhitespaces.ReplaceAllString(s, " ")
+	var line = w.line.String()
+	if line == "" || strings.HasSuffix(line, "\n") || strings.HasSuffix(line, " ") {
+		s = strings.TrimLeft(s, " ")
+	}
+	w.line.WriteString(s)
+}
+
+func (w *textWriter) children(n *Node) {
+	for _, child := range n.ChildNodes {
+		w.node(child)
+	}
+}
+
+func (w *textWriter) node(n *Node) {
+	if n.NodeType == textNode {
+		w.text(n.GetTextContent())
+		return
+	}
+	if n.NodeType != elementNode {
+		return
+	}
+
+	switch n.TagName {
+	case "BR":
+		w.line.WriteString("\n")
+	case "HR":
+		w.block("----")
+	case "UL", "OL":
+		w.list(n)
+	case "BLOCKQUOTE":
+		var content = w.sub(len(textQuoteIndent))
+		content.children(n)
+		w.block(prefixLines(content.String(), textQuoteIndent, ""))
+	case "PRE":
+		w.block(strings.Trim(strings.TrimRight(n.GetTextContent(), " \t\n"), "\n"))
+	case "TABLE":
+		if n.ReadabilityDataTable != nil && n.ReadabilityDataTable.value {
+			w.table(n)
+		} else {
+			w.flush()
+			w.children(n)
+			w.flush()
+		}
+	case "SCRIPT", "STYLE", "NOSCRIPT", "TEMPLATE", "HEAD":
+	case "H1", "H2", "H3", "H4", "H5", "H6", "LI":
+		w.flush()
+		w.children(n)
+		w.flush()
+	default:
+		if slices.Contains(markdownBlocks, n.TagName) {
+			w.flush()
+			w.children(n)
+			w.flush()
+		} else {
+			w.children(n)
+		}
+	}
+}
+
+func (w *textWriter) list(n *Node) {
+	var ordered = n.TagName == "OL"
+	var number = 1
+	if start, err := strconv.Atoi(strings.TrimSpace(n.GetAttribute("start"))); ordered && err == nil {
+		number = start
+	}
+	var items []string
+	var loose bool
+	for _, li := range n.Children {
+		if li.TagName != "LI" {
+			continue
+		}
+		var marker = "- "
+		if ordered {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+		var content = w.sub(len(marker))
+		content.children(li)
+		var item = content.String()
+		if len(li.getElementsByTagName("p")) == 0 && len(li.getElementsByTagName("pre")) == 0 {
+			// Items without paragraphs are tight, even with nested lists.
+			item = strings.ReplaceAll(item, "\n\n", "\n")
+		} else {
+			loose = true
+		}
+		items = append(items, marker+prefixLines(item, strings.Repeat(" ", len(marker)), "")[len(marker):])
+	}
+	if loose {
+		w.block(strings.Join(items, "\n\n"))
+	} else {
+		w.block(strings.Join(items, "\n"))
+	}
+}
+
+// Writes the given data table with its cells aligned in columns.
+func (w *textWriter) table(n *Node) {
+	for _, caption := range n.getElementsByTagName("caption") {
+		var content = w.sub(0)
+		content.children(caption)
+		w.block(content.String())
+	}
+	w.block(tableLayout(n))
+}
+
+// Returns the rows of the given table, one per line, with their cells aligned in columns.
+func tableLayout(n *Node) string {
+	var rows [][]string
+	var widths []int
+	for _, tr := range n.getElementsByTagName("tr") {
+		var row []string
+		for _, cell := range tr.Children {
+			if cell.TagName != "TD" && cell.TagName != "TH" {
+				continue
+			}
+			var content = &textWriter{}
+			content.children(cell)
+			row = append(row, strings.Join(strings.Fields(content.String()), " "))
+		}
+		if len(row) == 0 {
+			continue
+		}
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+		rows = append(rows, row)
+	}
+
+	var lines []string
+	for _, row := range rows {
+		var line strings.Builder
+		for i, cell := range row {
+			line.WriteString(cell)
+			if i < len(row)-1 {
+				line.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+			}
+		}
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Wraps the given line at the given width, between words. Words longer than the width
+// are not broken. A width of 0 leaves the line as is.
+func wrapLine(line string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(line) <= width {
+		return line
+	}
+	var wrapped strings.Builder
+	var length int
+	for _, word := range strings.Fields(line) {
+		var wordLength = utf8.RuneCountInString(word)
+		switch {
+		case length == 0:
+		case length+1+wordLength > width:
+			wrapped.WriteString("\n")
+			length = 0
+		default:
+			wrapped.WriteString(" ")
+			length++
+		}
+		wrapped.WriteString(word)
+		length += wordLength
+	}
+	return wrapped.String()
+}
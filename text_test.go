@@ -0,0 +1,87 @@
+package readability
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlainText(t *testing.T) {
+
+	var render = func(html string) string {
+		return PlainText(newDOMParser().parse("<html><body>"+html+"</body></html>", "http://fakehost/").Body)
+	}
+
+	testCases := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			"headings and paragraphs",
+			"<h2>The\n title</h2>\n   <p>Some   <b>bold</b>\n\t and <a href=\"http://fakehost/\">linked</a> text.</p>\n<p>Second<br />line</p><hr />",
+			"The title\n\nSome bold and linked text.\n\nSecond\nline\n\n----",
+		},
+		{
+			"nested lists",
+			"<ul><li>One</li><li>Two<ol start=\"3\"><li>Three</li><li>Four</li></ol></li></ul><ol><li><p>First</p><p>paragraph</p></li><li><p>Second</p></li></ol>",
+			"- One\n- Two\n  3. Three\n  4. Four\n\n1. First\n\n   paragraph\n\n2. Second",
+		},
+		{
+			"blockquotes",
+			"<p>He said:</p><blockquote><p>Quoted</p><blockquote><p>twice</p></blockquote></blockquote>",
+			"He said:\n\n    Quoted\n\n        twice",
+		},
+		{
+			"preformatted text",
+			"<p>Run:</p><pre><code>func main() {\n\tfmt.Println(\"hi\")\n}\n</code></pre>",
+			"Run:\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}",
+		},
+		{
+			"layout tables",
+			"<table><tr><td>Cell</td><td><p>Other cell</p></td></tr></table>",
+			"Cell\n\nOther cell",
+		},
+		{
+			"images and scripts",
+			`<p>Before<img src="http://fakehost/fox.jpg" alt="A fox" /><script>var x = 1;</script> after</p>`,
+			"Before after",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, render(tc.html))
+		})
+	}
+
+	t.Run("data tables", func(t *testing.T) {
+		var doc = newDOMParser().parse(`<html><body><table><caption>Results</caption><tr><th>Name</th><th>Score</th></tr><tr><td>Jane Doe</td><td>42</td></tr><tr><td>Al</td><td>7</td></tr></table></body></html>`, "http://fakehost/")
+		var table = doc.getElementsByTagName("table")[0]
+		table.ReadabilityDataTable = &readabilityDataTable{value: true}
+		assert.Equal(t, "Results\n\nName      Score\nJane Doe  42\nAl        7", PlainText(doc.Body))
+	})
+
+	t.Run("wrapping", func(t *testing.T) {
+		var doc = newDOMParser().parse(`<html><body><p>The quick brown fox jumps over the lazy dog.</p><ul><li>The quick brown fox jumps over the lazy dog.</li></ul><pre>The quick brown fox jumps over the lazy dog.</pre></body></html>`, "http://fakehost/")
+		assert.Equal(t, "The quick brown fox\njumps over the lazy\ndog.\n\n"+
+			"- The quick brown fox\n  jumps over the lazy\n  dog.\n\n"+
+			"The quick brown fox jumps over the lazy dog.", TextRenderer{Width: 20}.Render(doc.Body))
+	})
+}
+
+func TestTextSerializer(t *testing.T) {
+
+	var paragraph = "<p>" + strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5) + "</p>"
+	var html = `<html><body><article><h1>Title</h1>` + paragraph + `<ul><li>One</li><li>Two</li></ul>` + paragraph + `</article></body></html>`
+
+	reader, err := New(html, "http://fakehost/test/page.html", TextSerializer(PlainText))
+	assert.NoError(t, err)
+	result, err := reader.Parse()
+	assert.NoError(t, err)
+
+	var lorem = strings.TrimSpace(strings.Repeat("Lorem ipsum dolor sit amet, consectetur adipiscing elit. ", 5))
+	assert.Equal(t, "Title\n\n"+lorem+"\n\n- One\n- Two\n\n"+lorem, result.TextContent)
+	assert.Contains(t, result.HTMLContent, "<li>One</li>")
+}
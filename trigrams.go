@@ -0,0 +1,96 @@
+package readability
+
+// Sample texts of the languages written in the Latin alphabet, from which their trigram
+// profiles are built: the first articles of the Universal Declaration of Human Rights,
+// followed by a few sentences in the register of a news article.
+var languageSamples = map[string]string{
+	"en": `All human beings are born free and equal in dignity and rights. They are endowed with reason and conscience and should act towards one another in a spirit of brotherhood.
+Everyone is entitled to all the rights and freedoms set forth in this Declaration, without distinction of any kind, such as race, colour, sex, language, religion, political or other opinion, national or social origin, property, birth or other status.
+The city council approved the new budget on Tuesday after a long debate about the cost of public transport and the future of the old market. Residents who attended the meeting said they were worried that the changes would make their daily journeys longer, while the mayor argued that the investment was necessary to keep the network running for the next twenty years.
+Scientists have found that the ocean is warming faster than previously thought, which could have serious consequences for the weather, for fish stocks and for the people whose lives depend on them.`,
+
+	"fr": `Tous les êtres humains naissent libres et égaux en dignité et en droits. Ils sont doués de raison et de conscience et doivent agir les uns envers les autres dans un esprit de fraternité.
+Chacun peut se prévaloir de tous les droits et de toutes les libertés proclamés dans la présente Déclaration, sans distinction aucune, notamment de race, de couleur, de sexe, de langue, de religion, d'opinion politique ou de toute autre opinion.
+Le conseil municipal a adopté mardi le nouveau budget après un long débat sur le coût des transports publics et l'avenir de l'ancien marché. Les habitants qui assistaient à la réunion ont expliqué qu'ils craignaient que ces changements ne rallongent leurs trajets quotidiens, tandis que le maire a estimé que cet investissement était nécessaire pour faire fonctionner le réseau pendant les vingt prochaines années.
+Des scientifiques ont découvert que l'océan se réchauffe plus vite qu'on ne le pensait, ce qui pourrait avoir de graves conséquences sur le climat, sur les ressources de pêche et sur les populations qui en dépendent.`,
+
+	"de": `Alle Menschen sind frei und gleich an Würde und Rechten geboren. Sie sind mit Vernunft und Gewissen begabt und sollen einander im Geist der Brüderlichkeit begegnen.
+Jeder hat Anspruch auf alle in dieser Erklärung verkündeten Rechte und Freiheiten, ohne irgendeinen Unterschied, etwa nach Rasse, Hautfarbe, Geschlecht, Sprache, Religion, politischer oder sonstiger Überzeugung.
+Der Stadtrat hat am Dienstag nach einer langen Debatte über die Kosten des öffentlichen Nahverkehrs und die Zukunft des alten Marktes den neuen Haushalt beschlossen. Anwohner, die an der Sitzung teilnahmen, sagten, sie befürchteten, dass die Änderungen ihre täglichen Wege verlängern würden, während der Bürgermeister erklärte, die Investition sei notwendig, um das Netz in den nächsten zwanzig Jahren am Laufen zu halten.
+Wissenschaftler haben herausgefunden, dass sich der Ozean schneller erwärmt als bisher angenommen, was ernste Folgen für das Wetter, für die Fischbestände und für die Menschen haben könnte, die davon abhängen.`,
+
+	"es": `Todos los seres humanos nacen libres e iguales en dignidad y derechos y, dotados como están de razón y conciencia, deben comportarse fraternalmente los unos con los otros.
+Toda persona tiene todos los derechos y libertades proclamados en esta Declaración, sin distinción alguna de raza, color, sexo, idioma, religión, opinión política o de cualquier otra índole.
+El ayuntamiento aprobó el martes el nuevo presupuesto después de un largo debate sobre el coste del transporte público y el futuro del antiguo mercado. Los vecinos que asistieron a la reunión dijeron que temían que los cambios hicieran más largos sus desplazamientos diarios, mientras que el alcalde sostuvo que la inversión era necesaria para mantener la red en funcionamiento durante los próximos veinte años.
+Los científicos han descubierto que el océano se está calentando más rápido de lo que se pensaba, lo que podría tener graves consecuencias para el clima, para las poblaciones de peces y para las personas cuyas vidas dependen de ellas.`,
+
+	"it": `Tutti gli esseri umani nascono liberi ed eguali in dignità e diritti. Essi sono dotati di ragione e di coscienza e devono agire gli uni verso gli altri in spirito di fratellanza.
+Ad ogni individuo spettano tutti i diritti e tutte le libertà enunciate nella presente Dichiarazione, senza distinzione alcuna, per ragioni di razza, di colore, di sesso, di lingua, di religione, di opinione politica o di altro genere.
+Il consiglio comunale ha approvato martedì il nuovo bilancio dopo un lungo dibattito sul costo dei trasporti pubblici e sul futuro del vecchio mercato. I residenti che hanno partecipato alla riunione hanno detto di temere che i cambiamenti rendano più lunghi i loro spostamenti quotidiani, mentre il sindaco ha sostenuto che l'investimento era necessario per far funzionare la rete per i prossimi vent'anni.
+Gli scienziati hanno scoperto che l'oceano si sta riscaldando più velocemente di quanto si pensasse, il che potrebbe avere gravi conseguenze per il clima, per la pesca e per le persone la cui vita dipende da essa.`,
+
+	"pt": `Todos os seres humanos nascem livres e iguais em dignidade e em direitos. Dotados de razão e de consciência, devem agir uns para com os outros em espírito de fraternidade.
+Todos os seres humanos podem invocar os direitos e as liberdades proclamados na presente Declaração, sem distinção alguma, nomeadamente de raça, de cor, de sexo, de língua, de religião, de opinião política ou outra.
+A câmara municipal aprovou na terça-feira o novo orçamento depois de um longo debate sobre o custo dos transportes públicos e o futuro do antigo mercado. Os moradores que assistiram à reunião disseram que temiam que as mudanças tornassem as suas deslocações diárias mais longas, enquanto o presidente da câmara defendeu que o investimento era necessário para manter a rede a funcionar durante os próximos vinte anos.
+Os cientistas descobriram que o oceano está a aquecer mais depressa do que se pensava, o que pode ter consequências graves para o clima, para os recursos pesqueiros e para as pessoas cujas vidas dependem deles.`,
+
+	"nl": `Alle mensen worden vrij en gelijk in waardigheid en rechten geboren. Zij zijn begiftigd met verstand en geweten, en behoren zich jegens elkander in een geest van broederschap te gedragen.
+Een ieder heeft aanspraak op alle rechten en vrijheden, in deze Verklaring opgesomd, zonder enig onderscheid van welke aard ook, zoals ras, kleur, geslacht, taal, godsdienst, politieke of andere overtuiging.
+De gemeenteraad heeft dinsdag de nieuwe begroting goedgekeurd na een lang debat over de kosten van het openbaar vervoer en de toekomst van de oude markt. Bewoners die de vergadering bijwoonden, zeiden dat ze vreesden dat de veranderingen hun dagelijkse reizen langer zouden maken, terwijl de burgemeester stelde dat de investering nodig was om het netwerk de komende twintig jaar draaiende te houden.
+Wetenschappers hebben ontdekt dat de oceaan sneller opwarmt dan eerder werd gedacht, wat ernstige gevolgen kan hebben voor het weer, voor de visstand en voor de mensen wier leven daarvan afhangt.`,
+
+	"sv": `Alla människor är födda fria och lika i värde och rättigheter. De är utrustade med förnuft och samvete och bör handla gentemot varandra i en anda av broderskap.
+Var och en är berättigad till alla de rättigheter och friheter som uttalas i denna förklaring utan åtskillnad av något slag, såsom ras, hudfärg, kön, språk, religion, politisk eller annan uppfattning.
+Kommunfullmäktige godkände på tisdagen den nya budgeten efter en lång debatt om kostnaderna för kollektivtrafiken och framtiden för det gamla torget. Invånare som deltog i mötet sade att de var oroliga för att förändringarna skulle göra deras dagliga resor längre, medan kommunstyrelsens ordförande hävdade att investeringen var nödvändig för att hålla nätet igång under de kommande tjugo åren.
+Forskare har upptäckt att havet blir varmare snabbare än man tidigare trott, vilket kan få allvarliga följder för vädret, för fiskbestånden och för de människor vars liv är beroende av dem.`,
+
+	"da": `Alle mennesker er født frie og lige i værdighed og rettigheder. De er udstyret med fornuft og samvittighed, og de bør handle mod hverandre i en broderskabets ånd.
+Enhver har krav på alle de rettigheder og friheder, som nævnes i denne erklæring, uden forskel af nogen art, for eksempel på grund af race, farve, køn, sprog, religion, politisk eller anden anskuelse.
+Byrådet vedtog tirsdag det nye budget efter en lang debat om udgifterne til den kollektive trafik og fremtiden for det gamle torv. Borgere, der deltog i mødet, sagde, at de var bekymrede for, at ændringerne ville gøre deres daglige rejser længere, mens borgmesteren mente, at investeringen var nødvendig for at holde nettet kørende i de næste tyve år.
+Forskere har opdaget, at havet bliver varmere hurtigere end hidtil antaget, hvilket kan få alvorlige følger for vejret, for fiskebestandene og for de mennesker, hvis liv afhænger af dem.`,
+
+	"nb": `Alle mennesker er født frie og med samme menneskeverd og menneskerettigheter. De er utstyrt med fornuft og samvittighet og bør handle mot hverandre i brorskapets ånd.
+Enhver har krav på alle de rettigheter og friheter som er nevnt i denne erklæringen, uten forskjell av noen art, for eksempel på grunn av rase, farge, kjønn, språk, religion, politisk eller annen oppfatning.
+Bystyret vedtok tirsdag det nye budsjettet etter en lang debatt om kostnadene ved kollektivtransporten og framtiden til det gamle torget. Innbyggere som var til stede på møtet, sa at de fryktet at endringene ville gjøre de daglige reisene deres lengre, mens ordføreren mente at investeringen var nødvendig for å holde nettet i gang de neste tjue årene.
+Forskere har funnet ut at havet blir varmere raskere enn man tidligere trodde, noe som kan få alvorlige følger for været, for fiskebestandene og for menneskene som er avhengige av dem.`,
+
+	"fi": `Kaikki ihmiset syntyvät vapaina ja tasavertaisina arvoltaan ja oikeuksiltaan. Heille on annettu järki ja omatunto, ja heidän on toimittava toisiaan kohtaan veljeyden hengessä.
+Jokainen on oikeutettu kaikkiin tässä julistuksessa esitettyihin oikeuksiin ja vapauksiin ilman minkäänlaista rotuun, väriin, sukupuoleen, kieleen, uskontoon, poliittiseen tai muuhun mielipiteeseen perustuvaa erotusta.
+Kaupunginvaltuusto hyväksyi tiistaina uuden talousarvion pitkän keskustelun jälkeen, joka koski joukkoliikenteen kustannuksia ja vanhan torin tulevaisuutta. Kokoukseen osallistuneet asukkaat sanoivat pelkäävänsä, että muutokset pidentäisivät heidän päivittäisiä matkojaan, kun taas pormestari katsoi, että investointi oli välttämätön verkon pitämiseksi toiminnassa seuraavat kaksikymmentä vuotta.
+Tutkijat ovat havainneet, että meri lämpenee nopeammin kuin aiemmin luultiin, mikä voi aiheuttaa vakavia seurauksia säälle, kalakannoille ja ihmisille, joiden elämä on niistä riippuvainen.`,
+
+	"pl": `Wszyscy ludzie rodzą się wolni i równi pod względem swej godności i swych praw. Są oni obdarzeni rozumem i sumieniem i powinni postępować wobec innych w duchu braterstwa.
+Każdy człowiek posiada wszystkie prawa i wolności zawarte w niniejszej Deklaracji bez względu na różnice rasy, koloru skóry, płci, języka, wyznania, poglądów politycznych i innych przekonań.
+Rada miasta przyjęła we wtorek nowy budżet po długiej debacie na temat kosztów transportu publicznego i przyszłości starego targowiska. Mieszkańcy, którzy byli obecni na posiedzeniu, mówili, że obawiają się, iż zmiany wydłużą ich codzienne dojazdy, natomiast burmistrz przekonywał, że inwestycja jest konieczna, aby sieć mogła działać przez następne dwadzieścia lat.
+Naukowcy odkryli, że ocean ociepla się szybciej, niż wcześniej sądzono, co może mieć poważne konsekwencje dla pogody, dla zasobów ryb i dla ludzi, których życie od nich zależy.`,
+
+	"cs": `Všichni lidé rodí se svobodní a sobě rovní co do důstojnosti a práv. Jsou nadáni rozumem a svědomím a mají spolu jednat v duchu bratrství.
+Každý má všechna práva a všechny svobody, stanovené touto deklarací, bez jakéhokoli rozlišování podle rasy, barvy, pohlaví, jazyka, náboženství, politického nebo jiného smýšlení.
+Městské zastupitelstvo v úterý schválilo nový rozpočet po dlouhé debatě o nákladech na veřejnou dopravu a o budoucnosti starého tržiště. Obyvatelé, kteří se zasedání zúčastnili, uvedli, že se obávají, že změny prodlouží jejich každodenní cesty, zatímco starosta tvrdil, že investice je nezbytná, aby síť mohla fungovat dalších dvacet let.
+Vědci zjistili, že se oceán otepluje rychleji, než se dosud předpokládalo, což by mohlo mít vážné důsledky pro počasí, pro populace ryb a pro lidi, jejichž život na nich závisí.`,
+
+	"ro": `Toate ființele umane se nasc libere și egale în demnitate și în drepturi. Ele sunt înzestrate cu rațiune și conștiință și trebuie să se comporte unele față de altele în spiritul fraternității.
+Fiecare om se poate prevala de toate drepturile și libertățile proclamate în prezenta Declarație fără niciun fel de deosebire ca, de pildă, deosebirea de rasă, culoare, sex, limbă, religie, opinie politică sau orice altă opinie.
+Consiliul local a aprobat marți noul buget după o dezbatere lungă despre costul transportului public și viitorul vechii piețe. Locuitorii care au participat la ședință au spus că se tem că schimbările le vor lungi drumurile zilnice, în timp ce primarul a susținut că investiția era necesară pentru ca rețeaua să funcționeze în următorii douăzeci de ani.
+Oamenii de știință au descoperit că oceanul se încălzește mai repede decât se credea, ceea ce ar putea avea consecințe grave asupra vremii, asupra populațiilor de pești și asupra oamenilor ale căror vieți depind de acestea.`,
+
+	"hu": `Minden emberi lény szabadon születik és egyenlő méltósága és joga van. Az emberek, ésszel és lelkiismerettel bírván, egymással szemben testvéri szellemben kell hogy viseltessenek.
+Mindenki, bármely megkülönböztetésre, nevezetesen fajra, színre, nemre, nyelvre, vallásra, politikai vagy bármely más véleményre való tekintet nélkül hivatkozhat a jelen Nyilatkozatban kinyilvánított összes jogokra és szabadságokra.
+A városi közgyűlés kedden elfogadta az új költségvetést, miután hosszú vita folyt a tömegközlekedés költségeiről és a régi piac jövőjéről. Az ülésen részt vevő lakók azt mondták, attól tartanak, hogy a változások meghosszabbítják a napi utazásaikat, a polgármester viszont azt hangsúlyozta, hogy a beruházásra szükség van ahhoz, hogy a hálózat a következő húsz évben is működjön.
+A tudósok felfedezték, hogy az óceán gyorsabban melegszik, mint korábban gondolták, ami súlyos következményekkel járhat az időjárásra, a halállományra és azokra az emberekre nézve, akiknek az élete ettől függ.`,
+
+	"tr": `Bütün insanlar hür, haysiyet ve haklar bakımından eşit doğarlar. Akıl ve vicdana sahiptirler ve birbirlerine karşı kardeşlik zihniyeti ile hareket etmelidirler.
+Herkes, ırk, renk, cinsiyet, dil, din, siyasi veya diğer herhangi bir akide, milli veya içtimai menşe, servet, doğuş veya herhangi diğer bir fark gözetilmeksizin işbu Beyannamede ilan olunan tekmil haklardan ve bütün hürriyetlerden istifade edebilir.
+Belediye meclisi salı günü toplu taşımanın maliyeti ve eski pazarın geleceği hakkında uzun bir tartışmanın ardından yeni bütçeyi onayladı. Toplantıya katılan mahalle sakinleri, değişikliklerin günlük yolculuklarını uzatacağından endişe ettiklerini söyledi, belediye başkanı ise yatırımın ağın önümüzdeki yirmi yıl boyunca çalışmaya devam etmesi için gerekli olduğunu savundu.
+Bilim insanları okyanusun daha önce düşünülenden daha hızlı ısındığını keşfetti; bunun hava durumu, balık stokları ve hayatları bunlara bağlı olan insanlar için ciddi sonuçları olabilir.`,
+
+	"id": `Semua orang dilahirkan merdeka dan mempunyai martabat dan hak-hak yang sama. Mereka dikaruniai akal dan hati nurani dan hendaknya bergaul satu sama lain dalam semangat persaudaraan.
+Setiap orang berhak atas semua hak dan kebebasan yang tercantum di dalam Pernyataan ini dengan tidak ada kekecualian apa pun, seperti ras, warna kulit, jenis kelamin, bahasa, agama, politik atau pandangan lain.
+Dewan kota menyetujui anggaran baru pada hari Selasa setelah perdebatan panjang tentang biaya transportasi umum dan masa depan pasar lama. Warga yang menghadiri pertemuan itu mengatakan bahwa mereka khawatir perubahan tersebut akan membuat perjalanan sehari-hari mereka menjadi lebih lama, sementara wali kota berpendapat bahwa investasi itu diperlukan agar jaringan tetap berjalan selama dua puluh tahun ke depan.
+Para ilmuwan menemukan bahwa laut memanas lebih cepat daripada yang diperkirakan sebelumnya, yang dapat menimbulkan akibat serius bagi cuaca, bagi persediaan ikan, dan bagi orang-orang yang hidupnya bergantung padanya.`,
+
+	"vi": `Tất cả mọi người sinh ra đều được tự do và bình đẳng về nhân phẩm và quyền lợi. Mọi con người đều được tạo hóa ban cho lý trí và lương tâm và cần phải đối xử với nhau trong tình bằng hữu.
+Mọi người đều được hưởng tất cả những quyền và tự do nêu trong Tuyên ngôn này, không phân biệt chủng tộc, màu da, giới tính, ngôn ngữ, tôn giáo, quan điểm chính trị hay quan điểm khác.
+Hội đồng thành phố đã thông qua ngân sách mới vào thứ Ba sau một cuộc tranh luận dài về chi phí giao thông công cộng và tương lai của khu chợ cũ. Những người dân tham dự cuộc họp cho biết họ lo ngại rằng những thay đổi này sẽ làm cho việc đi lại hằng ngày của họ dài hơn, trong khi thị trưởng cho rằng khoản đầu tư này là cần thiết để mạng lưới tiếp tục hoạt động trong hai mươi năm tới.
+Các nhà khoa học đã phát hiện ra rằng đại dương đang nóng lên nhanh hơn so với suy nghĩ trước đây, điều này có thể gây ra hậu quả nghiêm trọng đối với thời tiết, nguồn cá và những người có cuộc sống phụ thuộc vào chúng.`,
+}
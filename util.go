@@ -2,7 +2,10 @@ package readability
 
 import (
 	"bytes"
+	"context"
+	"log/slog"
 	"slices"
+	"strings"
 
 	"github.com/andybalholm/cascadia"
 	"golang.org/x/net/html"
@@ -35,6 +38,41 @@ func anyOf(strings ...string) string {
 	return ""
 }
 
+// Returns the first non-empty list.
+func anyList(lists ...[]string) []string {
+	for _, list := range lists {
+		if len(list) != 0 {
+			return list
+		}
+	}
+	return nil
+}
+
+// Splits a comma-separated list, trimming its items and dropping the empty ones.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range commas.Split(s, -1) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Removes the duplicates from the given strings, ignoring case and keeping the first occurrence.
+func uniqueStrings(strs []string) []string {
+	var unique []string
+	var seen = make(map[string]bool)
+	for _, s := range strs {
+		var key = strings.ToLower(s)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, s)
+		}
+	}
+	return unique
+}
+
 func querySelectorAll(n *html.Node, query string) []*html.Node {
 	sel, err := cascadia.ParseGroup(query)
 	if err != nil {
@@ -77,3 +115,13 @@ func textContent(n *html.Node) string {
 
 	return buf.String()
 }
+
+var discardLogger = slog.New(discardHandler{})
+
+// A slog.Handler which discards every record.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }